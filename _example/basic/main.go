@@ -24,6 +24,8 @@ func main() {
 	//=> false
 	cron.Match(time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC))
 	//=> true
+	cron.MatchWithin(time.Date(2022, 11, 3, 10, 2, 30, 0, time.UTC), 3*time.Minute)
+	//=> 2022-11-03 10:00:00 +0000 UTC true
 
 	// NOTE: If you don't want to include `from`, add `1 * time.Minute`
 	cron.Next(time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC))
@@ -33,6 +35,11 @@ func main() {
 	cron.NextN(time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC), 3)
 	//=> [2022-11-03 10:00:00 +0000 UTC 2022-11-04 10:00:00 +0000 UTC 2022-11-05 10:00:00 +0000 UTC]
 
+	cron.Prev(time.Date(2022, 11, 3, 9, 0, 0, 0, time.UTC))
+	//=> 2022-11-02 10:00:00 +0000 UTC
+	cron.PrevN(time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC), 2)
+	//=> [2022-11-03 10:00:00 +0000 UTC 2022-11-02 10:00:00 +0000 UTC]
+
 	cron.Between(
 		time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC),
 		time.Date(2022, 11, 4, 10, 0, 0, 0, time.UTC),
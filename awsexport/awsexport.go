@@ -0,0 +1,259 @@
+// Package awsexport reads schedules from the JSON output of "aws events list-rules",
+// "aws scheduler list-schedules" and "aws scheduler get-schedule", so that they can be evaluated offline.
+package awsexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+)
+
+const (
+	StateEnabled  = "ENABLED"
+	StateDisabled = "DISABLED"
+)
+
+// Schedule is an EventBridge rule or an EventBridge Scheduler schedule.
+type Schedule struct {
+	Name string
+	// GroupName is the schedule group of a Scheduler schedule. It is empty for a rule.
+	GroupName string
+	// ScheduleExpression is "cron(...)", "rate(...)" or "at(...)".
+	ScheduleExpression string
+	// ScheduleExpressionTimezone is the time zone to evaluate the expression in. Empty means UTC.
+	ScheduleExpressionTimezone string
+	StartDate                  *time.Time
+	EndDate                    *time.Time
+	// State is StateEnabled, StateDisabled or another state of a rule
+	// (e.g. "ENABLED_WITH_ALL_CLOUDTRAIL_MANAGEMENT_EVENTS").
+	State string
+}
+
+type record struct {
+	Name                       string
+	GroupName                  string
+	ScheduleExpression         string
+	ScheduleExpressionTimezone string
+	StartDate                  json.RawMessage
+	EndDate                    json.RawMessage
+	State                      string
+}
+
+type list struct {
+	Rules     []*record
+	Schedules []*record
+}
+
+// Read reads the schedules from JSON documents.
+// A document is the output of "aws events list-rules" ({"Rules": [...]}), "aws scheduler list-schedules"
+// ({"Schedules": [...]}) or "aws scheduler get-schedule" (a schedule), or an array of schedules.
+// The documents may be concatenated. Rules without ScheduleExpression (i.e. event pattern rules) are skipped.
+// Note that "aws scheduler list-schedules" does not output ScheduleExpression,
+// so its schedules must be complemented with "aws scheduler get-schedule".
+func Read(r io.Reader) ([]*Schedule, error) {
+	dec := json.NewDecoder(r)
+	schedules := []*Schedule{}
+
+	for {
+		var raw json.RawMessage
+
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse AWS export: %w", err)
+		}
+
+		var records []*record
+		skipEventPatterns := false
+
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '[' {
+			if err := json.Unmarshal(raw, &records); err != nil {
+				return nil, fmt.Errorf("failed to parse AWS export: %w", err)
+			}
+		} else {
+			l := &list{}
+
+			if err := json.Unmarshal(raw, l); err != nil {
+				return nil, fmt.Errorf("failed to parse AWS export: %w", err)
+			}
+
+			if l.Rules != nil {
+				records = l.Rules
+				skipEventPatterns = true
+			} else if l.Schedules != nil {
+				records = l.Schedules
+			} else {
+				rec := &record{}
+
+				if err := json.Unmarshal(raw, rec); err != nil {
+					return nil, fmt.Errorf("failed to parse AWS export: %w", err)
+				}
+
+				records = []*record{rec}
+			}
+		}
+
+		for _, rec := range records {
+			if rec.ScheduleExpression == "" {
+				if skipEventPatterns {
+					continue
+				}
+
+				return nil, fmt.Errorf("schedule '%s' has no ScheduleExpression (use the output of 'aws scheduler get-schedule')", rec.Name)
+			}
+
+			s := &Schedule{
+				Name:                       rec.Name,
+				GroupName:                  rec.GroupName,
+				ScheduleExpression:         strings.TrimSpace(rec.ScheduleExpression),
+				ScheduleExpressionTimezone: rec.ScheduleExpressionTimezone,
+				State:                      rec.State,
+			}
+
+			var err error
+
+			if s.StartDate, err = parseTimestamp(rec.StartDate); err != nil {
+				return nil, fmt.Errorf("invalid StartDate of '%s': %w", rec.Name, err)
+			}
+
+			if s.EndDate, err = parseTimestamp(rec.EndDate); err != nil {
+				return nil, fmt.Errorf("invalid EndDate of '%s': %w", rec.Name, err)
+			}
+
+			schedules = append(schedules, s)
+		}
+	}
+
+	return schedules, nil
+}
+
+// parseTimestamp parses an ISO 8601 timestamp (the AWS CLI v2 default) or epoch seconds (the AWS CLI v1 default).
+func parseTimestamp(raw json.RawMessage) (*time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, err := time.Parse(time.RFC3339Nano, s)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &t, nil
+	}
+
+	sec, err := strconv.ParseFloat(string(raw), 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s as a timestamp", raw)
+	}
+
+	t := time.Unix(0, int64(sec*float64(time.Second))).UTC()
+	return &t, nil
+}
+
+// Enabled returns whether the schedule is not disabled.
+func (s *Schedule) Enabled() bool {
+	return s.State != StateDisabled
+}
+
+// Location returns the time zone to evaluate the expression in.
+func (s *Schedule) Location() (*time.Location, error) {
+	if s.ScheduleExpressionTimezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(s.ScheduleExpressionTimezone)
+}
+
+// Expressions returns the cron expressions of the schedule.
+// A rate expression is converted with Rate.ToCron, so it is anchored at 00:00 instead of the creation time.
+// A one-time schedule ("at(...)") has no cron expressions.
+func (s *Schedule) Expressions() ([]*cronplan.Expression, error) {
+	exp := s.ScheduleExpression
+
+	if strings.HasPrefix(exp, "cron(") && strings.HasSuffix(exp, ")") {
+		cron, err := cronplan.Parse(exp[len("cron(") : len(exp)-1])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*cronplan.Expression{cron}, nil
+	} else if strings.HasPrefix(exp, "rate(") {
+		rate, err := cronplan.ParseRate(exp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		exprs, _, err := rate.ToCron()
+		return exprs, err
+	} else if strings.HasPrefix(exp, "at(") {
+		return []*cronplan.Expression{}, nil
+	}
+
+	return nil, fmt.Errorf("invalid schedule expression '%s'", exp)
+}
+
+// Between returns the occurrences from from to to, evaluated in the time zone of the schedule
+// and limited by StartDate and EndDate. The occurrences are sorted and returned in the location of from.
+func (s *Schedule) Between(from time.Time, to time.Time) ([]time.Time, error) {
+	loc, err := s.Location()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs, err := s.Expressions()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.StartDate != nil && s.StartDate.After(from) {
+		from = s.StartDate.In(from.Location())
+	}
+
+	if s.EndDate != nil && s.EndDate.Before(to) {
+		to = s.EndDate.In(to.Location())
+	}
+
+	times := []time.Time{}
+
+	if strings.HasPrefix(s.ScheduleExpression, "at(") {
+		t, err := time.ParseInLocation("2006-01-02T15:04:05", strings.TrimSuffix(s.ScheduleExpression[len("at("):], ")"), loc)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule expression '%s'", s.ScheduleExpression)
+		}
+
+		if !t.Before(from) && !t.After(to) {
+			times = append(times, t.In(from.Location()))
+		}
+
+		return times, nil
+	}
+
+	for _, e := range exprs {
+		for _, t := range e.Between(from.In(loc), to.In(loc)) {
+			times = append(times, t.In(from.Location()))
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	// an occurrence that matches more than one expression is returned once
+	return slices.CompactFunc(times, time.Time.Equal), nil
+}
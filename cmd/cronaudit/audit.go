@@ -0,0 +1,122 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+)
+
+const (
+	kindMissed     = "missed"
+	kindUnexpected = "unexpected"
+	kindDuplicate  = "duplicate"
+	kindLate       = "late"
+)
+
+type finding struct {
+	Name      string     `json:"name"`
+	Kind      string     `json:"kind"`
+	Scheduled *time.Time `json:"scheduled,omitempty"`
+	Actual    *time.Time `json:"actual,omitempty"`
+	Delay     string     `json:"delay,omitempty"`
+}
+
+type summary struct {
+	Name       string `json:"name"`
+	Expr       string `json:"expr"`
+	Expected   int    `json:"expected"`
+	Runs       int    `json:"runs"`
+	OnTime     int    `json:"on_time"`
+	Late       int    `json:"late"`
+	Missed     int    `json:"missed"`
+	Duplicate  int    `json:"duplicate"`
+	Unexpected int    `json:"unexpected"`
+}
+
+type job struct {
+	name string
+	expr string
+	cron *cronplan.Expression
+	runs []time.Time
+}
+
+// audit pairs each scheduled time between start and end with the runs within the tolerance after it.
+func audit(j *job, start time.Time, end time.Time, within time.Duration, late time.Duration) (*summary, []finding) {
+	sum := &summary{Name: j.name, Expr: j.expr, Runs: len(j.runs)}
+	findings := []finding{}
+	// keyed on the Unix time: the schedule and the runs may be in different locations
+	paired := map[int64][]time.Time{}
+	expected := j.cron.Between(start, end)
+
+	for _, s := range expected {
+		paired[s.Unix()] = []time.Time{}
+	}
+
+	sort.Slice(j.runs, func(a, b int) bool {
+		return j.runs[a].Before(j.runs[b])
+	})
+
+	for _, r := range j.runs {
+		s, ok := j.cron.MatchWithin(r, within)
+
+		if !ok {
+			actual := r
+			findings = append(findings, finding{Name: j.name, Kind: kindUnexpected, Actual: &actual})
+			sum.Unexpected++
+			continue
+		}
+
+		if _, ok := paired[s.Unix()]; !ok {
+			expected = append(expected, s)
+		}
+
+		paired[s.Unix()] = append(paired[s.Unix()], r)
+	}
+
+	sort.Slice(expected, func(a, b int) bool {
+		return expected[a].Before(expected[b])
+	})
+
+	sum.Expected = len(expected)
+
+	for _, s := range expected {
+		scheduled := s
+		runs := paired[s.Unix()]
+
+		if len(runs) == 0 {
+			findings = append(findings, finding{Name: j.name, Kind: kindMissed, Scheduled: &scheduled})
+			sum.Missed++
+			continue
+		}
+
+		for i, r := range runs {
+			actual := r
+			delay := r.Sub(s)
+
+			if i > 0 {
+				findings = append(findings, finding{Name: j.name, Kind: kindDuplicate, Scheduled: &scheduled, Actual: &actual, Delay: delay.String()})
+				sum.Duplicate++
+			} else if delay > late {
+				findings = append(findings, finding{Name: j.name, Kind: kindLate, Scheduled: &scheduled, Actual: &actual, Delay: delay.String()})
+				sum.Late++
+			} else {
+				sum.OnTime++
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(a, b int) bool {
+		return findingTime(findings[a]).Before(findingTime(findings[b]))
+	})
+
+	return sum, findings
+}
+
+func findingTime(f finding) time.Time {
+	if f.Scheduled != nil {
+		return *f.Scheduled
+	}
+
+	return *f.Actual
+}
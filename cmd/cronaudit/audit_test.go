@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestAuditPunctual(t *testing.T) {
+	assert := assert.New(t)
+
+	cron, err := cronplan.Parse("*/5 * * * ? *")
+	assert.NoError(err)
+
+	runs := []time.Time{
+		time.Date(2024, 1, 1, 10, 0, 10, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 5, 5, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 10, 2, 0, time.UTC),
+	}
+
+	j := &job{expr: "*/5 * * * ? *", cron: cron, runs: runs}
+	sum, findings := audit(j, runs[0], runs[2], 5*time.Minute, time.Minute)
+	assert.Empty(findings)
+	assert.Equal(&summary{Expr: "*/5 * * * ? *", Expected: 3, Runs: 3, OnTime: 3}, sum)
+}
+
+func TestAuditMissed(t *testing.T) {
+	assert := assert.New(t)
+
+	cron, err := cronplan.Parse("*/5 * * * ? *")
+	assert.NoError(err)
+
+	runs := []time.Time{
+		time.Date(2024, 1, 1, 10, 0, 10, 0, time.UTC),
+		time.Date(2024, 1, 1, 10, 10, 2, 0, time.UTC),
+	}
+
+	j := &job{expr: "*/5 * * * ? *", cron: cron, runs: runs}
+	sum, findings := audit(j, runs[0], runs[1], 5*time.Minute, time.Minute)
+	scheduled := time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+	assert.Equal([]finding{{Kind: kindMissed, Scheduled: &scheduled}}, findings)
+	assert.Equal(&summary{Expr: "*/5 * * * ? *", Expected: 3, Runs: 2, OnTime: 2, Missed: 1}, sum)
+}
+
+func TestAuditMixedLocations(t *testing.T) {
+	assert := assert.New(t)
+
+	cron, err := cronplan.Parse("*/5 * * * ? *")
+	assert.NoError(err)
+
+	jst := time.FixedZone("+09:00", 9*60*60)
+
+	runs := []time.Time{
+		time.Date(2024, 1, 1, 19, 0, 10, 0, jst),
+		time.Date(2024, 1, 1, 19, 5, 5, 0, jst),
+		time.Date(2024, 1, 1, 10, 10, 2, 0, time.UTC),
+	}
+
+	j := &job{expr: "*/5 * * * ? *", cron: cron, runs: runs}
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	sum, findings := audit(j, start, runs[2], 5*time.Minute, time.Minute)
+	assert.Empty(findings)
+	assert.Equal(&summary{Expr: "*/5 * * * ? *", Expected: 3, Runs: 3, OnTime: 3}, sum)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	version string
+)
+
+type flags struct {
+	expr      string
+	cronFile  string
+	input     string
+	start     string
+	end       string
+	within    time.Duration
+	late      time.Duration
+	output    string
+	quiet     bool
+	tz        string
+	utcOffset string
+}
+
+func init() {
+	cmdLine := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+
+	cmdLine.Usage = func() {
+		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] CRON_EXPR [LOG_FILE]\n", cmdLine.Name())
+		fmt.Fprintf(cmdLine.Output(), "       %s -c CRON_FILE [OPTION] [LOG_FILE]\n", cmdLine.Name())
+		cmdLine.PrintDefaults()
+	}
+
+	flag.CommandLine = cmdLine
+}
+
+func parseFlags() *flags {
+	flags := &flags{}
+	flag.StringVar(&flags.cronFile, "c", "", "file of 'name expr' lines (log lines must be 'name date')")
+	flag.StringVar(&flags.start, "s", "", "start date (default: earliest run)")
+	flag.StringVar(&flags.end, "e", "", "end date (default: latest run)")
+	flag.DurationVar(&flags.within, "within", 5*time.Minute, "tolerance to pair a run with a scheduled time")
+	flag.DurationVar(&flags.late, "late", time.Minute, "report runs delayed more than the duration as late")
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json)")
+	flag.BoolVar(&flags.quiet, "q", false, "print nothing, exit with status 1 if there are findings")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersionAndExit()
+	}
+
+	if flags.output != "text" && flags.output != "json" {
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
+	if flags.within < 0 {
+		log.Fatal("'-within' must be >= 0")
+	}
+
+	args := flag.Args()
+
+	if flags.cronFile == "" {
+		if len(args) == 0 {
+			printUsageAndExit()
+		} else if len(args) > 2 {
+			log.Fatal("too many arguments")
+		}
+
+		flags.expr = strings.TrimSpace(args[0])
+		args = args[1:]
+	} else if len(args) > 1 {
+		log.Fatal("too many arguments")
+	}
+
+	if len(args) == 1 {
+		flags.input = args[0]
+	}
+
+	return flags
+}
+
+func printVersionAndExit() {
+	v := version
+
+	if v == "" {
+		v = "<nil>"
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), v)
+	os.Exit(0)
+}
+
+func printUsageAndExit() {
+	flag.CommandLine.Usage()
+	os.Exit(0)
+}
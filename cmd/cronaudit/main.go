@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+)
+
+var timeFormat = "Mon, 02 Jan 2006 15:04:05"
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	flags := parseFlags()
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		timeFormat += " MST"
+	}
+
+	r := regexp.MustCompile(`\s+`)
+	jobs := map[string]*job{}
+	names := []string{}
+
+	if flags.cronFile == "" {
+		entry, err := input.ParseArg(flags.expr)
+
+		if err != nil {
+			log.Fatalf("failed to parse cron expr: %s", err)
+		}
+
+		jobs[""] = &job{expr: entry.Expr, cron: entry.Cron}
+		names = append(names, "")
+	} else {
+		entries, err := input.Load(flags.cronFile)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, e := range entries {
+			// log lines are 'name date', so the jobs must be named
+			if e.Name == "" {
+				log.Fatal(&input.Error{File: e.File, Line: e.Line, Err: errors.New("'name expr' is required")})
+			}
+
+			if _, ok := jobs[e.Name]; !ok {
+				names = append(names, e.Name)
+			}
+
+			jobs[e.Name] = &job{name: e.Name, expr: e.Expr, cron: e.Cron}
+		}
+	}
+
+	file, err := input.Open(flags.input)
+
+	if err != nil {
+		log.Fatalf("failed to open %s: %s", flags.input, err)
+	}
+
+	defer file.Close()
+	scanner := input.NewScanner(file)
+	lineno := 0
+	var earliest, latest time.Time
+
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		name := ""
+		date := line
+
+		if flags.cronFile != "" {
+			fields := r.Split(line, 2)
+
+			if len(fields) < 2 {
+				log.Fatalf("too few fields: line %d: %s", lineno, line)
+			}
+
+			name = fields[0]
+			date = fields[1]
+		}
+
+		t, err := dateparse.ParseIn(date, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse date: line %d: %s", lineno, err)
+		}
+
+		j, ok := jobs[name]
+
+		if !ok {
+			log.Fatalf("unknown job: line %d: %s", lineno, name)
+		}
+
+		t = zone.In(t, evalLoc)
+		j.runs = append(j.runs, t)
+
+		if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+
+		if latest.IsZero() || t.After(latest) {
+			latest = t
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	// a slot before the earliest run is not in the log, so it is not reported as missed
+	start := earliest
+	end := latest
+
+	if flags.start != "" {
+		var err error
+		start, err = dateparse.ParseIn(flags.start, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse start date: %s", err)
+		}
+	}
+
+	if flags.end != "" {
+		var err error
+		end, err = dateparse.ParseIn(flags.end, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse end date: %s", err)
+		}
+	}
+
+	start = zone.In(start, evalLoc)
+	end = zone.In(end, evalLoc)
+
+	if earliest.IsZero() && (flags.start == "" || flags.end == "") {
+		log.Fatal("no runs in the log (specify '-s' and '-e' to audit an empty log)")
+	}
+
+	sort.Strings(names)
+	summaries := []*summary{}
+	findings := []finding{}
+
+	for _, name := range names {
+		sum, fs := audit(jobs[name], start, end, flags.within, flags.late)
+		summaries = append(summaries, sum)
+		findings = append(findings, fs...)
+	}
+
+	if !flags.quiet {
+		if flags.output == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err := enc.Encode(map[string]any{
+				"start":    start,
+				"end":      end,
+				"summary":  summaries,
+				"findings": findings,
+			})
+
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			printText(summaries, findings, flags.cronFile != "", loc)
+		}
+	}
+
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printText(summaries []*summary, findings []finding, named bool, loc *time.Location) {
+	if len(findings) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		if named {
+			fmt.Fprint(w, "NAME\t")
+		}
+
+		fmt.Fprintln(w, "KIND\tSCHEDULED\tACTUAL\tDELAY")
+
+		for _, f := range findings {
+			scheduled, actual, delay := "-", "-", "-"
+
+			if f.Scheduled != nil {
+				scheduled = f.Scheduled.In(loc).Format(timeFormat)
+			}
+
+			if f.Actual != nil {
+				actual = f.Actual.In(loc).Format(timeFormat)
+			}
+
+			if f.Delay != "" {
+				delay = "+" + f.Delay
+			}
+
+			if named {
+				fmt.Fprintf(w, "%s\t", f.Name)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Kind, scheduled, actual, delay)
+		}
+
+		w.Flush()
+		fmt.Println()
+	}
+
+	for _, s := range summaries {
+		if named {
+			fmt.Printf("%s: ", s.Name)
+		}
+
+		fmt.Printf("expected=%d runs=%d on_time=%d late=%d missed=%d duplicate=%d unexpected=%d\n",
+			s.Expected, s.Runs, s.OnTime, s.Late, s.Missed, s.Duplicate, s.Unexpected)
+	}
+}
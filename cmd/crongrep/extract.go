@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+type extractor func(line string) (string, error)
+
+func extractLine(line string) (string, error) {
+	return line, nil
+}
+
+func newRegexpExtractor(expr string) (extractor, error) {
+	r, err := regexp.Compile(expr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(line string) (string, error) {
+		m := r.FindStringSubmatch(line)
+
+		if m == nil {
+			return "", fmt.Errorf("'%s' does not match", expr)
+		}
+
+		if len(m) > 1 {
+			return m[1], nil
+		}
+
+		return m[0], nil
+	}, nil
+}
+
+// newColumnExtractor extracts the columns specified by "N" or "N-M" (1-based).
+// If delim is empty, columns are separated by whitespace.
+func newColumnExtractor(delim string, columns string) (extractor, error) {
+	start, end, found := strings.Cut(columns, "-")
+
+	if !found {
+		end = start
+	}
+
+	from, err := strconv.Atoi(start)
+
+	if err != nil || from < 1 {
+		return nil, fmt.Errorf("invalid column: %s", columns)
+	}
+
+	to, err := strconv.Atoi(end)
+
+	if err != nil || to < from {
+		return nil, fmt.Errorf("invalid column: %s", columns)
+	}
+
+	return func(line string) (string, error) {
+		var fields []string
+		sep := delim
+
+		if delim == "" {
+			fields = strings.Fields(line)
+			sep = " "
+		} else {
+			fields = strings.Split(line, delim)
+		}
+
+		if len(fields) < to {
+			return "", fmt.Errorf("too few columns: %d < %d", len(fields), to)
+		}
+
+		return strings.Join(fields[from-1:to], sep), nil
+	}, nil
+}
+
+// newJSONExtractor extracts the value at a dot-separated key path (e.g. "detail.time" or "records.0.ts")
+// from a JSON line.
+func newJSONExtractor(path string) extractor {
+	keys := strings.Split(path, ".")
+
+	return func(line string) (string, error) {
+		var v any
+		dec := json.NewDecoder(strings.NewReader(line))
+		dec.UseNumber()
+
+		if err := dec.Decode(&v); err != nil {
+			return "", err
+		}
+
+		for _, key := range keys {
+			switch node := v.(type) {
+			case map[string]any:
+				child, ok := node[key]
+
+				if !ok {
+					return "", fmt.Errorf("key not found: %s", path)
+				}
+
+				v = child
+			case []any:
+				i, err := strconv.Atoi(key)
+
+				if err != nil || i < 0 || len(node) <= i {
+					return "", fmt.Errorf("key not found: %s", path)
+				}
+
+				v = node[i]
+			default:
+				return "", fmt.Errorf("key not found: %s", path)
+			}
+		}
+
+		switch value := v.(type) {
+		case string:
+			return value, nil
+		case json.Number:
+			return value.String(), nil
+		default:
+			return "", fmt.Errorf("value is not a string or number: %s", path)
+		}
+	}
+}
+
+func parseTime(s string, epoch string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	switch epoch {
+	case "s":
+		f, err := strconv.ParseFloat(s, 64)
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot convert to epoch seconds from %s", s)
+		}
+
+		return time.UnixMilli(int64(f * 1000)).UTC(), nil
+	case "ms":
+		n, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot convert to epoch milliseconds from %s", s)
+		}
+
+		return time.UnixMilli(n).UTC(), nil
+	default:
+		return dateparse.ParseIn(s, loc)
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 var (
@@ -13,7 +15,17 @@ var (
 )
 
 type flags struct {
-	expr string
+	expr      string
+	regexp    string
+	delim     string
+	column    string
+	jsonKey   string
+	epoch     string
+	invert    bool
+	onError   string
+	within    time.Duration
+	tz        string
+	utcOffset string
 }
 
 func init() {
@@ -29,6 +41,16 @@ func init() {
 
 func parseFlags() *flags {
 	flags := &flags{}
+	flag.StringVar(&flags.regexp, "re", "", "extract the timestamp with a regexp (the first capture group, or the whole match)")
+	flag.StringVar(&flags.delim, "d", "", "column delimiter for '-f' (default whitespace)")
+	flag.StringVar(&flags.column, "f", "", "extract the timestamp from columns 'N' or 'N-M'")
+	flag.StringVar(&flags.jsonKey, "j", "", "extract the timestamp from JSON lines with a key path (e.g. 'detail.time')")
+	flag.StringVar(&flags.epoch, "epoch", "", "parse the timestamp as epoch time (s, ms)")
+	flag.BoolVar(&flags.invert, "v", false, "select non-matching lines")
+	flag.DurationVar(&flags.within, "within", 0, "select lines within the duration after a scheduled time, with the scheduled time and the delay (e.g. '3m')")
+	flag.StringVar(&flags.onError, "on-error", "warn", "policy for lines with unparsable timestamp (skip, warn, fail)")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
 	showVersion := flag.Bool("version", false, "print version and exit")
 	flag.Parse()
 
@@ -44,6 +66,34 @@ func parseFlags() *flags {
 
 	flags.expr = strings.TrimSpace(args[0])
 
+	nExtractors := 0
+
+	for _, f := range []string{flags.regexp, flags.column, flags.jsonKey} {
+		if f != "" {
+			nExtractors++
+		}
+	}
+
+	if nExtractors > 1 {
+		log.Fatal("'-re', '-f' and '-j' cannot be used together")
+	}
+
+	if flags.delim != "" && flags.column == "" {
+		log.Fatal("'-d' requires '-f'")
+	}
+
+	if flags.epoch != "" && flags.epoch != "s" && flags.epoch != "ms" {
+		log.Fatalf("invalid epoch unit: %s", flags.epoch)
+	}
+
+	if flags.within < 0 {
+		log.Fatal("'-within' must be >= 0")
+	}
+
+	if flags.onError != "skip" && flags.onError != "warn" && flags.onError != "fail" {
+		log.Fatalf("invalid error policy: %s", flags.onError)
+	}
+
 	return flags
 }
 
@@ -1,13 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
-	"github.com/araddon/dateparse"
-	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
 )
 
 func init() {
@@ -16,24 +16,86 @@ func init() {
 
 func main() {
 	flags := parseFlags()
-	cron, err := cronplan.Parse(flags.expr)
+	entry, err := input.ParseArg(flags.expr)
 
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("failed to parse cron expr: %s", err)
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
+	cron := entry.Cron
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		t, err := dateparse.ParseAny(line)
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	timeFormat := "Mon, 02 Jan 2006 15:04:05"
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		timeFormat += " MST"
+	}
+
+	var extract extractor
+
+	if flags.regexp != "" {
+		extract, err = newRegexpExtractor(flags.regexp)
+
+		if err != nil {
+			log.Fatalf("failed to compile regexp: %s", err)
+		}
+	} else if flags.column != "" {
+		extract, err = newColumnExtractor(flags.delim, flags.column)
 
 		if err != nil {
 			log.Fatal(err)
 		}
+	} else if flags.jsonKey != "" {
+		extract = newJSONExtractor(flags.jsonKey)
+	} else {
+		extract = extractLine
+	}
+
+	scanner := input.NewScanner(os.Stdin)
+	lineno := 0
+
+	for scanner.Scan() {
+		lineno++
+		line := scanner.Text()
+		s, err := extract(line)
+
+		if err == nil {
+			var t time.Time
+			t, err = parseTime(s, flags.epoch, loc)
+
+			if err == nil {
+				t = zone.In(t, evalLoc)
 
-		if cron.Match(t) {
-			fmt.Println(line)
+				if flags.within > 0 {
+					scheduled, ok := cron.MatchWithin(t, flags.within)
+
+					if ok && !flags.invert {
+						fmt.Printf("%s\t%s\t+%s\n", line, scheduled.In(loc).Format(timeFormat), t.Sub(scheduled))
+					} else if !ok && flags.invert {
+						fmt.Println(line)
+					}
+				} else if cron.Match(t) != flags.invert {
+					fmt.Println(line)
+				}
+
+				continue
+			}
+		}
+
+		switch flags.onError {
+		case "fail":
+			log.Fatalf("line %d: %s", lineno, err)
+		case "warn":
+			log.Printf("warning: line %d: %s", lineno, err)
 		}
 	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+var (
+	version string
+)
+
+type flags struct {
+	input  string
+	system bool
+	tz     string
+	output string
+}
+
+func init() {
+	cmdLine := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+
+	cmdLine.Usage = func() {
+		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] [CRONTAB]\n", cmdLine.Name())
+		cmdLine.PrintDefaults()
+	}
+
+	flag.CommandLine = cmdLine
+}
+
+func parseFlags() *flags {
+	flags := &flags{}
+	flag.BoolVar(&flags.system, "system", false, "read a system crontab that has the user column (e.g. /etc/crontab)")
+	flag.StringVar(&flags.tz, "tz", "", "time zone of the crontab, shifting the expressions to UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json)")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersionAndExit()
+	}
+
+	if flags.output != "text" && flags.output != "json" {
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
+	args := flag.Args()
+
+	if len(args) > 1 {
+		log.Fatal("too many arguments")
+	} else if len(args) == 1 {
+		flags.input = args[0]
+	}
+
+	return flags
+}
+
+func printVersionAndExit() {
+	v := version
+
+	if v == "" {
+		v = "<nil>"
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), v)
+	os.Exit(0)
+}
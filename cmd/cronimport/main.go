@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/crontab"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+type rule struct {
+	Name        string            `json:"name"`
+	Line        int               `json:"line"`
+	Schedule    string            `json:"schedule"`
+	User        string            `json:"user,omitempty"`
+	Command     string            `json:"command"`
+	Env         map[string]string `json:"env,omitempty"`
+	Timezone    string            `json:"timezone,omitempty"`
+	Expressions []string          `json:"expressions"`
+}
+
+func main() {
+	flags := parseFlags()
+	file, err := input.Open(flags.input)
+
+	if err != nil {
+		log.Fatalf("failed to open %s: %s", flags.input, err)
+	}
+
+	entries, err := crontab.Read(file, flags.system)
+	file.Close()
+	failed := false
+
+	if err != nil {
+		var lineErr *crontab.Error
+
+		if !errors.As(err, &lineErr) {
+			log.Fatal(err)
+		}
+
+		for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+			log.Printf("skipped: %s", err)
+		}
+
+		failed = true
+	}
+
+	rules := []*rule{}
+	names := map[string]int{}
+	now := time.Now()
+
+	for _, e := range entries {
+		tz := e.Timezone
+
+		if tz == "" {
+			tz = flags.tz
+		}
+
+		exprs := e.Expressions
+
+		if tz != "" {
+			exprs, err = toUTC(exprs, tz, now)
+
+			if err != nil {
+				log.Printf("skipped: line %d: %s", e.Line, err)
+				failed = true
+				continue
+			}
+		}
+
+		name := e.Name()
+		names[name]++
+
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[name])
+		}
+
+		r := &rule{
+			Name:     name,
+			Line:     e.Line,
+			Schedule: e.Schedule,
+			User:     e.User,
+			Command:  e.Command,
+			Env:      e.Env,
+			Timezone: e.Timezone,
+		}
+
+		for _, expr := range exprs {
+			r.Expressions = append(r.Expressions, expr.String())
+		}
+
+		rules = append(rules, r)
+	}
+
+	if flags.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+
+		if err := enc.Encode(rules); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		for _, r := range rules {
+			for i, expr := range r.Expressions {
+				name := r.Name
+
+				if len(r.Expressions) > 1 {
+					name = fmt.Sprintf("%s.%d", name, i+1)
+				}
+
+				fmt.Printf("%s\t%s\n", name, expr)
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// toUTC rewrites the expressions evaluated in the time zone into expressions evaluated in UTC
+// with the offset for the year from now.
+func toUTC(exprs []*cronplan.Expression, tz string, now time.Time) ([]*cronplan.Expression, error) {
+	loc, err := time.LoadLocation(tz)
+
+	if err != nil {
+		return nil, err
+	}
+
+	shifted := []*cronplan.Expression{}
+
+	for _, e := range exprs {
+		ee, err := cronplan.ShiftZone(e, loc, time.UTC, now)
+
+		if err != nil {
+			return nil, err
+		}
+
+		shifted = append(shifted, ee...)
+	}
+
+	return shifted, nil
+}
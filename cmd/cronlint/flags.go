@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	version string
+)
+
+type flags struct {
+	files     []string
+	output    string
+	levels    map[string]string
+	listRules bool
+	tz        string
+	utcOffset string
+}
+
+type config struct {
+	Rules map[string]string `json:"rules"`
+}
+
+func init() {
+	cmdLine := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+
+	cmdLine.Usage = func() {
+		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] [FILE...]\n", cmdLine.Name())
+		cmdLine.PrintDefaults()
+	}
+
+	flag.CommandLine = cmdLine
+}
+
+func parseFlags() *flags {
+	flags := &flags{}
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json, sarif)")
+	configFile := flag.String("config", "", `JSON file to configure the rules (e.g. '{"rules":{"CL004":"off","CL005":"error"}}')`)
+	disable := flag.String("disable", "", "comma-separated rule IDs to disable (e.g. 'CL003,CL004')")
+	flag.BoolVar(&flags.listRules, "list-rules", false, "print the rules and exit")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to display dates, evaluating expressions in UTC (e.g. '+05:30')")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersionAndExit()
+	}
+
+	if flags.output != "text" && flags.output != "json" && flags.output != "sarif" {
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
+	flags.levels = map[string]string{}
+
+	for _, r := range rules {
+		flags.levels[r.ID] = r.Level
+	}
+
+	if *configFile != "" {
+		cfg := &config{}
+		buf, err := os.ReadFile(*configFile)
+
+		if err != nil {
+			log.Fatalf("failed to read config: %s", err)
+		}
+
+		if err := json.Unmarshal(buf, cfg); err != nil {
+			log.Fatalf("failed to parse config: %s: %s", *configFile, err)
+		}
+
+		for id, level := range cfg.Rules {
+			if ruleByID(id) == nil {
+				log.Fatalf("unknown rule: %s", id)
+			}
+
+			switch level {
+			case levelError, levelWarning, levelNote, levelOff:
+				flags.levels[id] = level
+			default:
+				log.Fatalf("invalid level for %s: %s (must be error, warning, note or off)", id, level)
+			}
+		}
+	}
+
+	if *disable != "" {
+		for _, id := range strings.Split(*disable, ",") {
+			id = strings.TrimSpace(id)
+
+			if ruleByID(id) == nil {
+				log.Fatalf("unknown rule: %s", id)
+			}
+
+			flags.levels[id] = levelOff
+		}
+	}
+
+	flags.files = flag.Args()
+
+	return flags
+}
+
+func printVersionAndExit() {
+	v := version
+
+	if v == "" {
+		v = "<nil>"
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), v)
+	os.Exit(0)
+}
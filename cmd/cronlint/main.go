@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+type finding struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	RuleID  string `json:"rule_id"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	Name    string `json:"name,omitempty"`
+	Expr    string `json:"expr"`
+}
+
+// suppression is a set of rule IDs disabled by a comment. An empty set disables all rules.
+type suppression map[string]bool
+
+func (s suppression) has(id string) bool {
+	return s != nil && (len(s) == 0 || s[id])
+}
+
+func parseIDs(s string) suppression {
+	ids := suppression{}
+
+	for _, id := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+// fieldColumn returns the 1-based column of the nth field in the expression.
+func fieldColumn(expr string, field int) int {
+	n := -1
+	space := true
+
+	for i, r := range expr {
+		if unicode.IsSpace(r) {
+			space = true
+		} else if space {
+			space = false
+			n++
+
+			if n == field {
+				return utf8.RuneCountInString(expr[:i]) + 1
+			}
+		}
+	}
+
+	return 1
+}
+
+func suppressed(ss []suppression, id string) bool {
+	for _, s := range ss {
+		if s.has(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readSuppressions returns the suppressions of each line by the comments.
+func readSuppressions(lines []string) map[int][]suppression {
+	suppressions := map[int][]suppression{}
+	var disabled, nextLine suppression
+
+	for i, line := range lines {
+		content, comment := input.SplitComment(line)
+		lineDisabled := nextLine
+		nextLine = nil
+
+		if strings.TrimSpace(content) == "" {
+			switch {
+			case strings.HasPrefix(comment, "cronlint-disable-next-line"):
+				nextLine = parseIDs(strings.TrimPrefix(comment, "cronlint-disable-next-line"))
+			case strings.HasPrefix(comment, "cronlint-disable-line"):
+				// there is nothing to disable on a comment line
+			case strings.HasPrefix(comment, "cronlint-disable"):
+				disabled = parseIDs(strings.TrimPrefix(comment, "cronlint-disable"))
+			case strings.HasPrefix(comment, "cronlint-enable"):
+				disabled = nil
+			}
+
+			continue
+		}
+
+		if d, ok := strings.CutPrefix(comment, "cronlint-disable-line"); ok {
+			lineDisabled = parseIDs(d)
+		}
+
+		suppressions[i+1] = []suppression{disabled, lineDisabled}
+	}
+
+	return suppressions
+}
+
+// exprColumn returns the 0-based column of the expression in the line, after the name if any.
+func exprColumn(line string, name string, expr string) int {
+	start := 0
+
+	if i := strings.Index(line, name); name != "" && i >= 0 {
+		start = i + len(name)
+	}
+
+	if i := strings.Index(line[start:], expr); i >= 0 {
+		return utf8.RuneCountInString(line[:start+i])
+	}
+
+	return 0
+}
+
+// lintFile lints the entries read by the shared loader, so that "@include", CSV and JSON or YAML lists are linted
+// with their own file and line. The errors of the input are returned with the findings.
+func lintFile(name string, levels map[string]string, now time.Time, loc *time.Location) ([]*finding, error) {
+	filename, src, err := input.ReadFile(name)
+
+	if err != nil {
+		log.Fatalf("failed to read %s: %s", name, err)
+	}
+
+	entries, inputErr := input.ParseRaw(filename, src)
+	lines := map[string][]string{}
+	suppressions := map[string]map[int][]suppression{}
+	findings := []*finding{}
+
+	for _, e := range entries {
+		if _, ok := lines[e.File]; !ok {
+			// an included file is read again for the comments
+			if e.File != filename {
+				src, err = os.ReadFile(e.File)
+
+				if err != nil {
+					log.Fatalf("failed to read %s: %s", e.File, err)
+				}
+			}
+
+			lines[e.File] = strings.Split(string(src), "\n")
+			suppressions[e.File] = readSuppressions(lines[e.File])
+		}
+
+		line := ""
+
+		if e.Line >= 1 && e.Line <= len(lines[e.File]) {
+			line = lines[e.File][e.Line-1]
+		}
+
+		column := exprColumn(line, e.Name, e.Expr)
+
+		for _, p := range lint(e.Expr, now, loc) {
+			level := levels[p.rule.ID]
+
+			if level == levelOff || suppressed(suppressions[e.File][e.Line], p.rule.ID) {
+				continue
+			}
+
+			col := p.column
+
+			if p.field >= 0 {
+				col = fieldColumn(e.Expr, p.field)
+			}
+
+			findings = append(findings, &finding{
+				File:    e.File,
+				Line:    e.Line,
+				Column:  column + col,
+				RuleID:  p.rule.ID,
+				Level:   level,
+				Message: p.msg,
+				Name:    e.Name,
+				Expr:    e.Expr,
+			})
+		}
+	}
+
+	return findings, inputErr
+}
+
+func main() {
+	flags := parseFlags()
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	if flags.listRules {
+		printRules(flags.levels)
+		return
+	}
+
+	now := zone.In(time.Now(), evalLoc)
+	findings := []*finding{}
+	inputErrs := []error{}
+	files := flags.files
+
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	for _, name := range files {
+		fs, err := lintFile(name, flags.levels, now, loc)
+		findings = append(findings, fs...)
+
+		if err != nil {
+			inputErrs = append(inputErrs, err)
+		}
+	}
+
+	switch flags.output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(findings); err != nil {
+			log.Fatal(err)
+		}
+	case "sarif":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(newSarif(findings, flags.levels)); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		for _, f := range findings {
+			fmt.Printf("%s:%d:%d: %s: %s [%s]\n", f.File, f.Line, f.Column, f.Level, f.Message, f.RuleID)
+		}
+	}
+
+	// the lines that cannot be read are not linted, so they fail the check
+	if len(inputErrs) > 0 {
+		log.Print(errors.Join(inputErrs...))
+		os.Exit(1)
+	}
+
+	for _, f := range findings {
+		if f.Level == levelError || f.Level == levelWarning {
+			os.Exit(1)
+		}
+	}
+}
+
+func printRules(levels map[string]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tLEVEL\tDESCRIPTION")
+
+	for _, r := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.ID, r.Name, levels[r.ID], r.Description)
+	}
+
+	w.Flush()
+}
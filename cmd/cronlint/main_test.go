@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.txt": "# jobs\nreport 0 10 ? * L *\nheartbeat  * * * * ? *  # cronlint-disable-line CL004\n@include more.yml\n@include jobs.csv\n@include missing.txt\n",
+		"more.yml": "- cron(*/7 * * * ? *)\n# cronlint-disable-next-line\n- 0 25 * * ? *\n- name: step\n  expression: 10/7 * * * ? *\n",
+		"jobs.csv": "name,expression\nevery,* * * * ? *\n",
+	}
+
+	for name, src := range files {
+		assert.NoError(os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+
+	levels := map[string]string{}
+
+	for _, r := range rules {
+		levels[r.ID] = r.Level
+	}
+
+	findings, err := lintFile(filepath.Join(dir, "main.txt"), levels, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+	assert.EqualError(err, filepath.Join(dir, "main.txt")+":6: no files match '"+filepath.Join(dir, "missing.txt")+"'")
+	actual := []string{}
+
+	for _, f := range findings {
+		actual = append(actual, fmt.Sprintf("%s:%d:%d: %s", filepath.Base(f.File), f.Line, f.Column, f.RuleID))
+	}
+
+	assert.Equal([]string{
+		"main.txt:2:17: CL001",
+		"more.yml:1:8: CL003",
+		"more.yml:5:15: CL003",
+		"jobs.csv:2:7: CL004",
+	}, actual)
+}
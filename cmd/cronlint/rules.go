@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+)
+
+const (
+	levelError   = "error"
+	levelWarning = "warning"
+	levelNote    = "note"
+	levelOff     = "off"
+)
+
+const (
+	fieldMinute = iota
+	fieldHour
+	fieldDayOfMonth
+	fieldMonth
+	fieldDayOfWeek
+	fieldYear
+)
+
+type rule struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Level       string `json:"level"`
+}
+
+var rules = []*rule{
+	{"CL000", "parse-error", "The expression cannot be parsed.", levelError},
+	{"CL001", "day-of-week-last", "'L' alone in day-of-week means SAT, not the last day of the month.", levelWarning},
+	{"CL002", "nearest-weekday-skips-months", "'29W' to '31W' skip the months that do not have the day.", levelWarning},
+	{"CL003", "uneven-step", "The step does not divide the field range evenly, so the gap changes where the sequence restarts.", levelWarning},
+	{"CL004", "every-minute", "The expression fires every minute.", levelWarning},
+	{"CL005", "past-year", "A year or a year range is in the past.", levelWarning},
+	{"CL006", "question-mark", "Exactly one of day-of-month and day-of-week must be '?'.", levelError},
+	{"CL007", "nth-day-of-week", "'#5' fires only in some months, and '#0' or '#6' and above never fire.", levelWarning},
+	{"CL008", "no-future-occurrences", "The expression never fires again.", levelError},
+}
+
+func ruleByID(id string) *rule {
+	for _, r := range rules {
+		if r.ID == id {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// problem is a rule violation in an expression.
+// If field is negative, column is the 1-based column in the expression.
+type problem struct {
+	rule   *rule
+	field  int
+	column int
+	msg    string
+}
+
+type stepField struct {
+	name     string
+	min      int
+	max      int
+	restarts string
+}
+
+var stepFields = map[int]stepField{
+	fieldMinute:     {"minute", 0, 59, "every hour"},
+	fieldHour:       {"hour", 0, 23, "every day"},
+	fieldDayOfMonth: {"day-of-month", 1, 31, "on the 1st of every month"},
+	fieldMonth:      {"month", 1, 12, "every year"},
+	fieldDayOfWeek:  {"day-of-week", 0, 6, "every week"},
+}
+
+// checkStep reports the step of a wildcard, a number or a range ending at the end of the field range
+// that leaves an uneven gap where the sequence restarts.
+// A range ending earlier is a window of its own, so its step is not checked.
+func checkStep(field int, exp fmt.Stringer, start int, end int, bottom *int) *problem {
+	if bottom == nil {
+		return nil
+	}
+
+	f := stepFields[field]
+
+	if *bottom == 0 {
+		return &problem{rule: ruleByID("CL003"), field: field,
+			msg: fmt.Sprintf("the step of '%s' in %s is 0, so the step is ignored", exp, f.name)}
+	}
+
+	if end != f.max || start > end {
+		return nil
+	}
+
+	n := (end - start) / *bottom
+
+	if n < 1 {
+		return nil
+	}
+
+	last := start + n**bottom
+
+	if gap := f.max - f.min + 1 - (last - start); gap != *bottom {
+		return &problem{rule: ruleByID("CL003"), field: field,
+			msg: fmt.Sprintf("the step of '%s' in %s does not divide %d-%d evenly: the sequence restarts %s after %d", exp, f.name, f.min, f.max, f.restarts, last)}
+	}
+
+	return nil
+}
+
+// lint checks an expression. now is the time to evaluate the expression and loc is the location to display dates.
+func lint(exp string, now time.Time, loc *time.Location) []*problem {
+	cron, err := cronplan.Parse(exp)
+
+	if err != nil {
+		var serr *cronplan.SyntaxError
+
+		if errors.As(err, &serr) {
+			return []*problem{{rule: ruleByID("CL000"), field: -1, column: serr.Column, msg: serr.Msg}}
+		} else if strings.Contains(err.Error(), "'?'") {
+			return []*problem{{rule: ruleByID("CL006"), field: fieldDayOfMonth, msg: err.Error()}}
+		}
+
+		return []*problem{{rule: ruleByID("CL000"), field: -1, column: 1, msg: err.Error()}}
+	}
+
+	problems := []*problem{}
+	add := func(p *problem) {
+		if p != nil {
+			problems = append(problems, p)
+		}
+	}
+
+	for _, e := range cron.Minute.Exps {
+		start, end := 0, 59
+
+		if e.Range != nil {
+			start, end = e.Range.Start.Int(), e.Range.End.Int()
+		} else if e.Number != nil {
+			start = e.Number.Int()
+		}
+
+		add(checkStep(fieldMinute, e, start, end, e.Bottom))
+	}
+
+	for _, e := range cron.Hour.Exps {
+		start, end := 0, 23
+
+		if e.Range != nil {
+			start, end = e.Range.Start.Int(), e.Range.End.Int()
+		} else if e.Number != nil {
+			start = e.Number.Int()
+		}
+
+		add(checkStep(fieldHour, e, start, end, e.Bottom))
+	}
+
+	for _, e := range cron.DayOfMonth.Exps {
+		if e.NearestWeekday != nil && e.NearestWeekday.Int() >= 29 {
+			add(&problem{rule: ruleByID("CL002"), field: fieldDayOfMonth,
+				msg: fmt.Sprintf("'%s' skips the months that do not have day %d; use 'LW' for the last weekday of the month", e, e.NearestWeekday.Int())})
+		} else if e.Wildcard || e.Range != nil || e.Number != nil {
+			start, end := 1, 31
+
+			if e.Range != nil {
+				start, end = e.Range.Start.Int(), e.Range.End.Int()
+			} else if e.Number != nil {
+				start = e.Number.Int()
+			}
+
+			add(checkStep(fieldDayOfMonth, e, start, end, e.Bottom))
+		}
+	}
+
+	for _, e := range cron.Month.Exps {
+		start, end := 1, 12
+
+		if e.Range != nil {
+			start, end = e.Range.Start.Int(), e.Range.End.Int()
+		} else if e.Month != nil {
+			start = e.Month.Int()
+		}
+
+		add(checkStep(fieldMonth, e, start, end, e.Bottom))
+	}
+
+	for _, e := range cron.DayOfWeek.Exps {
+		if e.Last != nil && e.Last.Wday == nil {
+			add(&problem{rule: ruleByID("CL001"), field: fieldDayOfWeek,
+				msg: "'L' in day-of-week means SAT; use 'L' in day-of-month for the last day of the month or e.g. 'FRIL' for the last Friday"})
+		} else if e.Nth != nil {
+			if e.Nth.Nth < 1 || e.Nth.Nth > 5 {
+				add(&problem{rule: ruleByID("CL007"), field: fieldDayOfWeek,
+					msg: fmt.Sprintf("'%s' never fires: the nth must be 1-5", e)})
+			} else if e.Nth.Nth == 5 {
+				add(&problem{rule: ruleByID("CL007"), field: fieldDayOfWeek,
+					msg: fmt.Sprintf("'%s' fires only in the months that have five %ss; use '%sL' for the last one", e, e.Nth.Wday.Weekday(), e.Nth.Wday)})
+			}
+		} else if e.Wildcard || e.Range != nil || e.Wday != nil {
+			start, end := 0, 6
+
+			if e.Range != nil {
+				start, end = int(e.Range.Start.Weekday()), int(e.Range.End.Weekday())
+			} else if e.Wday != nil {
+				start = int(e.Wday.Weekday())
+			}
+
+			add(checkStep(fieldDayOfWeek, e, start, end, e.Bottom))
+		}
+	}
+
+	every := true
+
+	for m := 0; m <= 59; m++ {
+		if !cron.Minute.Match(time.Date(2000, 1, 1, 0, m, 0, 0, time.UTC)) {
+			every = false
+			break
+		}
+	}
+
+	if every {
+		add(&problem{rule: ruleByID("CL004"), field: fieldMinute,
+			msg: fmt.Sprintf("'%s' fires every minute", cron)})
+	}
+
+	if cron.Next(now).IsZero() {
+		msg := "the expression never fires"
+
+		if prev := cron.Prev(now); !prev.IsZero() {
+			msg = fmt.Sprintf("the expression never fires again: the last occurrence was %s", prev.In(loc).Format(time.RFC3339))
+		}
+
+		add(&problem{rule: ruleByID("CL008"), field: -1, column: 1, msg: msg})
+	} else {
+		for _, e := range cron.Year.Exps {
+			var end int
+
+			if e.Range != nil {
+				end = e.Range.End.Int()
+			} else if e.Number != nil && e.Bottom == nil {
+				end = e.Number.Int()
+			} else {
+				continue
+			}
+
+			if end < now.Year() {
+				add(&problem{rule: ruleByID("CL005"), field: fieldYear,
+					msg: fmt.Sprintf("'%s' in year is in the past", e)})
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintStep(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		exp      string
+		expected []string
+	}{
+		{"*/7 * * * ? *", []string{"the step of '*/7' in minute does not divide 0-59 evenly: the sequence restarts every hour after 56"}},
+		{"0-59/7 * * * ? *", []string{"the step of '0-59/7' in minute does not divide 0-59 evenly: the sequence restarts every hour after 56"}},
+		{"10-50/15 * * * ? *", []string{}},
+		{"0 9-17/2 * * ? *", []string{}},
+		{"0 0 1-31/2 * ? *", []string{"the step of '1-31/2' in day-of-month does not divide 1-31 evenly: the sequence restarts on the 1st of every month after 31"}},
+		{"0 0 ? * MON-FRI/2 *", []string{}},
+		{"50-10/7 * * * ? *", []string{}},
+		{"10-59/7 * * * ? *", []string{"the step of '10-59/7' in minute does not divide 0-59 evenly: the sequence restarts every hour after 59"}},
+		{"*/15 * * * ? *", []string{}},
+		{"10-55/15 * * * ? *", []string{}},
+		{"0 0-22/2 * * ? *", []string{}},
+		{"0 0 ? * SUN-SAT/2 *", []string{"the step of 'SUN-SAT/2' in day-of-week does not divide 0-6 evenly: the sequence restarts every week after 6"}},
+		{"0 10-20 * * ? *", []string{}},
+	}
+
+	for _, t := range tt {
+		actual := []string{}
+
+		for _, p := range lint(t.exp, now, time.UTC) {
+			if p.rule.ID == "CL003" {
+				actual = append(actual, p.msg)
+			}
+		}
+
+		assert.Equal(t.expected, actual, t.exp)
+	}
+}
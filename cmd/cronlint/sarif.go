@@ -0,0 +1,127 @@
+package main
+
+import (
+	"path/filepath"
+)
+
+// SARIF 2.1.0 (https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version,omitempty"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     *sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Enabled bool   `json:"enabled"`
+	Level   string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	RuleIndex int              `json:"ruleIndex"`
+	Level     string           `json:"level"`
+	Message   *sarifMessage    `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func newSarif(findings []*finding, levels map[string]string) *sarifLog {
+	driver := &sarifDriver{
+		Name:           "cronlint",
+		Version:        version,
+		InformationURI: "https://github.com/winebarrel/cronplan",
+		Rules:          []*sarifRule{},
+	}
+
+	index := map[string]int{}
+
+	for i, r := range rules {
+		cfg := &sarifConfiguration{Enabled: levels[r.ID] != levelOff, Level: levels[r.ID]}
+
+		if !cfg.Enabled {
+			cfg.Level = r.Level
+		}
+
+		driver.Rules = append(driver.Rules, &sarifRule{
+			ID:                   r.ID,
+			Name:                 r.Name,
+			ShortDescription:     &sarifMessage{Text: r.Description},
+			DefaultConfiguration: cfg,
+		})
+
+		index[r.ID] = i
+	}
+
+	results := []*sarifResult{}
+
+	for _, f := range findings {
+		results = append(results, &sarifResult{
+			RuleID:    f.RuleID,
+			RuleIndex: index[f.RuleID],
+			Level:     f.Level,
+			Message:   &sarifMessage{Text: f.Message},
+			Locations: []*sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{
+					ArtifactLocation: &sarifArtifactLocation{URI: filepath.ToSlash(f.File)},
+					Region:           &sarifRegion{StartLine: f.Line, StartColumn: f.Column},
+				},
+			}},
+		})
+	}
+
+	return &sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []*sarifRun{{Tool: &sarifTool{Driver: driver}, Results: results}},
+	}
+}
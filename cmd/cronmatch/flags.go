@@ -17,9 +17,15 @@ var (
 )
 
 type flags struct {
-	h    int
-	expr string
-	t    string
+	h         int
+	tz        string
+	utcOffset string
+	verbose   bool
+	batch     bool
+	output    string
+	expr      string
+	t         string
+	input     string
 }
 
 func init() {
@@ -27,6 +33,7 @@ func init() {
 
 	cmdLine.Usage = func() {
 		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] CRON_EXPR DATE\n", cmdLine.Name())
+		fmt.Fprintf(cmdLine.Output(), "       %s -b [OPTION] CRON_EXPR [FILE]\n", cmdLine.Name())
 		cmdLine.PrintDefaults()
 	}
 
@@ -35,7 +42,12 @@ func init() {
 
 func parseFlags() *flags {
 	flags := &flags{}
-	flag.IntVar(&flags.h, "h", 0, "hour to add")
+	flag.IntVar(&flags.h, "h", 0, "hour to add (deprecated: use '-tz' or '-utc-offset')")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
+	flag.BoolVar(&flags.verbose, "v", false, "explain which field accepted or rejected the date")
+	flag.BoolVar(&flags.batch, "b", false, "batch mode (read dates from FILE or stdin, one per line)")
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json)")
 	var noColor = flag.Bool("no-color", !isatty.IsTerminal(os.Stdout.Fd()), "disable color output")
 	showVersion := flag.Bool("version", false, "print version and exit")
 	flag.Parse()
@@ -44,18 +56,38 @@ func parseFlags() *flags {
 		printVersionAndExit()
 	}
 
+	if flags.output != "text" && flags.output != "json" {
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
+	if flags.h != 0 && (flags.tz != "" || flags.utcOffset != "") {
+		log.Fatal("'-h' cannot be used with '-tz' or '-utc-offset'")
+	}
+
 	args := flag.Args()
 
 	if len(args) == 0 {
 		printUsageAndExit()
-	} else if len(args) < 2 {
-		log.Fatal("too few arguments")
-	} else if len(args) > 2 {
-		log.Fatal("too many arguments")
 	}
 
 	flags.expr = strings.TrimSpace(args[0])
-	flags.t = strings.TrimSpace(args[1])
+
+	if flags.batch {
+		if len(args) > 2 {
+			log.Fatal("too many arguments")
+		} else if len(args) == 2 {
+			flags.input = args[1]
+		}
+	} else {
+		if len(args) < 2 {
+			log.Fatal("too few arguments")
+		} else if len(args) > 2 {
+			log.Fatal("too many arguments")
+		}
+
+		flags.t = strings.TrimSpace(args[1])
+	}
+
 	color.NoColor = *noColor
 
 	return flags
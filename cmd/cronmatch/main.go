@@ -1,57 +1,255 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/araddon/dateparse"
 	"github.com/fatih/color"
 	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
 )
 
+var timeFormat = "Mon, 02 Jan 2006 15:04:05"
+
 func init() {
 	log.SetFlags(0)
 }
 
+type result struct {
+	Date         string     `json:"date"`
+	Match        bool       `json:"match"`
+	Prev         *time.Time `json:"prev,omitempty"`
+	PrevDistance string     `json:"prev_distance,omitempty"`
+	Next         *time.Time `json:"next,omitempty"`
+	NextDistance string     `json:"next_distance,omitempty"`
+	t            time.Time
+}
+
+func check(cron *cronplan.Expression, date string, h int, loc *time.Location, evalLoc *time.Location) (*result, error) {
+	t, err := dateparse.ParseIn(date, loc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	offset := time.Duration(h) * time.Hour
+	t = zone.In(t, evalLoc).Add(offset)
+
+	r := &result{
+		Date:  date,
+		Match: cron.Match(t),
+		t:     t,
+	}
+
+	if !r.Match {
+		if prev := cron.Prev(t); !prev.IsZero() {
+			p := prev.Add(-offset).In(loc)
+			r.Prev = &p
+			r.PrevDistance = t.Sub(prev).String()
+		}
+
+		if next := cron.Next(t); !next.IsZero() {
+			n := next.Add(-offset).In(loc)
+			r.Next = &n
+			r.NextDistance = next.Sub(t).String()
+		}
+	}
+
+	return r, nil
+}
+
 func main() {
 	flags := parseFlags()
 
-	cron, err := cronplan.Parse(flags.expr)
+	entry, err := input.ParseArg(flags.expr)
 
 	if err != nil {
 		log.Fatalf("failed to parse cron expr: %s", err)
 	}
 
-	t, err := dateparse.ParseAny(flags.t)
+	cron := entry.Cron
+
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
 
 	if err != nil {
-		log.Fatalf("failed to parse date: %s", err)
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		timeFormat += " MST"
+	}
+
+	if flags.batch {
+		batch(cron, flags, loc, evalLoc)
+		return
 	}
 
-	t = t.Add(time.Duration(flags.h) * time.Hour)
-	m := cron.Match(t)
+	r, err := check(cron, flags.t, flags.h, loc, evalLoc)
+
+	if err != nil {
+		log.Fatalf("failed to parse date: %s", err)
+	}
 
-	if m {
+	if flags.output == "json" {
+		printJSON(r)
+	} else if r.Match {
 		var buf strings.Builder
 		fmt.Fprintf(&buf, "'%s' matches '%s'", flags.expr, flags.t)
 
 		if flags.h != 0 {
 			fmt.Fprintf(&buf, " (offset: %dh)", flags.h)
+		} else if flags.tz != "" || flags.utcOffset != "" {
+			fmt.Fprintf(&buf, " (time zone: %s)", loc)
 		}
 
 		color.Green(buf.String())
+
+		if flags.verbose {
+			printExplanation(cron.Explain(r.t))
+		}
 	} else {
 		var buf strings.Builder
 		fmt.Fprintf(&buf, "'%s' does not match '%s'", flags.expr, flags.t)
 
 		if flags.h != 0 {
 			fmt.Fprintf(&buf, " (offset: %dh)", flags.h)
+		} else if flags.tz != "" || flags.utcOffset != "" {
+			fmt.Fprintf(&buf, " (time zone: %s)", loc)
 		}
 
 		color.Red(buf.String())
+
+		if r.Prev != nil {
+			fmt.Printf("prev: %s (%s before)\n", r.Prev.Format(timeFormat), r.PrevDistance)
+		} else {
+			fmt.Println("prev: none")
+		}
+
+		if r.Next != nil {
+			fmt.Printf("next: %s (%s after)\n", r.Next.Format(timeFormat), r.NextDistance)
+		} else {
+			fmt.Println("next: none")
+		}
+
+		if flags.verbose {
+			printExplanation(cron.Explain(r.t))
+		}
+	}
+
+	if !r.Match {
 		os.Exit(1)
 	}
 }
+
+func batch(cron *cronplan.Expression, flags *flags, loc *time.Location, evalLoc *time.Location) {
+	file, err := input.Open(flags.input)
+
+	if err != nil {
+		log.Fatalf("failed to open %s: %s", flags.input, err)
+	}
+
+	defer file.Close()
+
+	scanner := input.NewScanner(file)
+	results := []*result{}
+	lineno := 0
+
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		r, err := check(cron, line, flags.h, loc, evalLoc)
+
+		if err != nil {
+			log.Fatalf("failed to parse date: line %d: %s", lineno, err)
+		}
+
+		results = append(results, r)
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	if flags.output == "json" {
+		printJSON(results)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tMATCH\tPREV\tNEXT")
+
+		for _, r := range results {
+			match := color.GreenString("yes")
+			prev := "-"
+			next := "-"
+
+			if !r.Match {
+				match = color.RedString("no")
+
+				if r.Prev != nil {
+					prev = fmt.Sprintf("%s (-%s)", r.Prev.Format(timeFormat), r.PrevDistance)
+				}
+
+				if r.Next != nil {
+					next = fmt.Sprintf("%s (+%s)", r.Next.Format(timeFormat), r.NextDistance)
+				}
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Date, match, prev, next)
+		}
+
+		w.Flush()
+	}
+
+	for _, r := range results {
+		if !r.Match {
+			os.Exit(1)
+		}
+	}
+}
+
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printExplanation(explanation *cronplan.Explanation) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tEXPR\tVALUE\tMATCH\tBY\tNEAREST")
+
+	for _, f := range explanation.Fields {
+		var match, by, nearest string
+
+		if f.Match {
+			match = color.GreenString("yes")
+			by = fmt.Sprintf("%s (%s)", f.Exp, f.Kind)
+			nearest = "-"
+		} else {
+			match = color.RedString("no")
+			by = "-"
+			nearest = f.Nearest
+
+			if nearest == "" {
+				nearest = "(none)"
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Field, f.Expr, f.Value, match, by, nearest)
+	}
+
+	w.Flush()
+}
@@ -14,9 +14,17 @@ var (
 )
 
 type flags struct {
-	n    int
-	h    int
-	expr string
+	n         int
+	h         int
+	tz        string
+	utcOffset string
+	from      string
+	to        string
+	prev      bool
+	output    string
+	layout    string
+	relative  bool
+	expr      string
 }
 
 func init() {
@@ -32,8 +40,16 @@ func init() {
 
 func parseFlags() *flags {
 	flags := &flags{}
-	flag.IntVar(&flags.h, "h", 0, "hour to add")
+	flag.IntVar(&flags.h, "h", 0, "hour to add (deprecated: use '-tz' or '-utc-offset')")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
 	flag.IntVar(&flags.n, "n", 10, "number of next triggers")
+	flag.StringVar(&flags.from, "from", "", "from date (default current date)")
+	flag.StringVar(&flags.to, "to", "", "to date (show all triggers between '-from' and '-to')")
+	flag.BoolVar(&flags.prev, "prev", false, "show previous triggers in reverse order")
+	flag.StringVar(&flags.output, "o", "text", "output format (text, rfc3339, epoch, json)")
+	flag.StringVar(&flags.layout, "layout", "", "Go time layout for text output (default \"Mon, 02 Jan 2006 15:04:05\", with zone abbreviation if a time zone is specified)")
+	flag.BoolVar(&flags.relative, "r", false, "show time relative to now")
 	showVersion := flag.Bool("version", false, "print version and exit")
 	flag.Parse()
 
@@ -51,10 +67,20 @@ func parseFlags() *flags {
 
 	flags.expr = strings.TrimSpace(args[0])
 
+	if flags.h != 0 && (flags.tz != "" || flags.utcOffset != "") {
+		log.Fatal("'-h' cannot be used with '-tz' or '-utc-offset'")
+	}
+
 	if flags.n < 1 {
 		log.Fatal("'-n' must be >= 1")
 	}
 
+	switch flags.output {
+	case "text", "rfc3339", "epoch", "json":
+	default:
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
 	return flags
 }
 
@@ -1,28 +1,158 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/winebarrel/cronplan/v2"
+	"github.com/araddon/dateparse"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
 )
 
 func init() {
 	log.SetFlags(0)
 }
 
+type trigger struct {
+	Time     time.Time `json:"time"`
+	Epoch    int64     `json:"epoch"`
+	Relative string    `json:"relative,omitempty"`
+}
+
 func main() {
 	flags := parseFlags()
-	cron, err := cronplan.Parse(flags.expr)
+	entry, err := input.ParseArg(flags.expr)
 
 	if err != nil {
 		log.Fatalf("failed to parse cron expr: %s", err)
 	}
 
-	triggers := cron.NextN(time.Now(), flags.n)
+	cron := entry.Cron
+
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	layout := flags.layout
+
+	if layout == "" {
+		layout = "Mon, 02 Jan 2006 15:04:05"
+
+		if flags.tz != "" || flags.utcOffset != "" {
+			layout += " MST"
+		}
+	}
+
+	now := time.Now()
+	from := now
+
+	if flags.from != "" {
+		from, err = dateparse.ParseIn(flags.from, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse from date: %s", err)
+		}
+	}
+
+	from = zone.In(from, evalLoc)
+
+	var triggers []time.Time
+
+	if flags.to != "" {
+		to, err := dateparse.ParseIn(flags.to, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse to date: %s", err)
+		}
+
+		to = zone.In(to, evalLoc)
+
+		if flags.prev {
+			triggers = cron.Between(to, from)
+			slices.Reverse(triggers)
+		} else {
+			triggers = cron.Between(from, to)
+		}
+	} else if flags.prev {
+		triggers = cron.PrevN(from, flags.n)
+	} else {
+		triggers = cron.NextN(from, flags.n)
+	}
+
+	offset := time.Duration(flags.h) * time.Hour
+	list := make([]*trigger, 0, len(triggers))
 
 	for _, t := range triggers {
-		fmt.Println(t.Add(time.Duration(flags.h) * time.Hour).Format("Mon, 02 Jan 2006 15:04:05"))
+		tr := &trigger{
+			Time:  t.Add(offset).In(loc),
+			Epoch: t.Unix(),
+		}
+
+		if flags.relative {
+			tr.Relative = relative(t.Sub(now))
+		}
+
+		list = append(list, tr)
+	}
+
+	if flags.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(list); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	for _, tr := range list {
+		var s string
+
+		switch flags.output {
+		case "rfc3339":
+			s = tr.Time.Format(time.RFC3339)
+		case "epoch":
+			s = fmt.Sprint(tr.Epoch)
+		default:
+			s = tr.Time.Format(layout)
+		}
+
+		if flags.relative {
+			s += "\t" + tr.Relative
+		}
+
+		fmt.Println(s)
+	}
+}
+
+// relative formats d like "in 3h12m" or "3h12m ago".
+func relative(d time.Duration) string {
+	future := d >= 0
+
+	if !future {
+		d = -d
+	}
+
+	s := d.Truncate(time.Minute).String()
+	s = strings.TrimSuffix(s, "0s")
+
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	if s == "" {
+		return "now"
+	} else if future {
+		return "in " + s
+	} else {
+		return s + " ago"
 	}
 }
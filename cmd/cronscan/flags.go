@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+var (
+	version string
+)
+
+type flags struct {
+	paths  []string
+	output string
+	check  bool
+}
+
+func init() {
+	cmdLine := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+
+	cmdLine.Usage = func() {
+		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] [PATH...]\n", cmdLine.Name())
+		cmdLine.PrintDefaults()
+	}
+
+	flag.CommandLine = cmdLine
+}
+
+func parseFlags() *flags {
+	flags := &flags{}
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json)")
+	flag.BoolVar(&flags.check, "check", false, "validate the expressions and print the invalid ones")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersionAndExit()
+	}
+
+	if flags.output != "text" && flags.output != "json" {
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
+	flags.paths = flag.Args()
+
+	if len(flags.paths) == 0 {
+		flags.paths = []string{"."}
+	}
+
+	return flags
+}
+
+func printVersionAndExit() {
+	v := version
+
+	if v == "" {
+		v = "<nil>"
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), v)
+	os.Exit(0)
+}
@@ -0,0 +1,32 @@
+module github.com/winebarrel/cronplan/v2/cmd/cronscan
+
+go 1.25.0
+
+toolchain go1.26.5
+
+replace github.com/winebarrel/cronplan/v2 => ../..
+
+replace github.com/winebarrel/cronplan/v2/kube => ../../kube
+
+replace github.com/winebarrel/cronplan/v2/scan => ../../scan
+
+require (
+	github.com/winebarrel/cronplan/v2 v2.0.0-00010101000000-000000000000
+	github.com/winebarrel/cronplan/v2/kube v0.0.0-00010101000000-000000000000
+	github.com/winebarrel/cronplan/v2/scan v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/agext/levenshtein v1.2.1 // indirect
+	github.com/alecthomas/participle/v2 v2.1.4 // indirect
+	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
+	github.com/apparentlymart/go-textseg/v17 v17.0.1 // indirect
+	github.com/hashicorp/hcl/v2 v2.25.0 // indirect
+	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
+	github.com/zclconf/go-cty v1.19.0 // indirect
+	golang.org/x/mod v0.29.0 // indirect
+	golang.org/x/sync v0.18.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	golang.org/x/tools v0.38.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/kube"
+	"github.com/winebarrel/cronplan/v2/scan"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+type schedule struct {
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Resource   string `json:"resource"`
+	Source     string `json:"source"`
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// parse returns the cron expressions of a "cron(...)" or "rate(...)" expression or a Kubernetes CronJob schedule.
+// A rate expression is converted to cron expressions, and the note describes the difference if any.
+func parse(s *scan.Schedule) ([]*cronplan.Expression, string, error) {
+	exp := s.Expression
+
+	if s.Source == scan.SourceKubernetes {
+		exprs, err := cronJob(s).Expressions()
+		return exprs, "", err
+	} else if strings.HasPrefix(exp, "cron(") && strings.HasSuffix(exp, ")") {
+		cron, err := cronplan.Parse(strings.TrimSuffix(strings.TrimPrefix(exp, "cron("), ")"))
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return []*cronplan.Expression{cron}, "", nil
+	} else if strings.HasPrefix(exp, "rate(") {
+		rate, err := cronplan.ParseRate(exp)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return rate.ToCron()
+	}
+
+	return nil, "", fmt.Errorf("'%s' is not a cron or rate expression", exp)
+}
+
+// validate returns the error of the expression or the time zone.
+// A rate expression that cannot be converted to cron expressions is still valid.
+func validate(s *scan.Schedule) error {
+	if strings.HasPrefix(s.Expression, "rate(") {
+		if _, err := cronplan.ParseRate(s.Expression); err != nil {
+			return err
+		}
+	} else if _, _, err := parse(s); err != nil {
+		return err
+	}
+
+	_, err := location(s)
+	return err
+}
+
+func cronJob(s *scan.Schedule) *kube.CronJob {
+	return &kube.CronJob{Schedule: s.Expression, TimeZone: s.Timezone}
+}
+
+// location returns the time zone to evaluate the expression in.
+// A Kubernetes CronJob schedule may have the time zone in the "CRON_TZ=" or "TZ=" prefix.
+func location(s *scan.Schedule) (*time.Location, error) {
+	if s.Source == scan.SourceKubernetes {
+		return cronJob(s).Location()
+	} else if s.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(s.Timezone)
+}
+
+// toUTC rewrites the expressions evaluated in the time zone of the schedule into expressions evaluated in UTC
+// with the offset for the year from now.
+func toUTC(s *scan.Schedule, exprs []*cronplan.Expression, now time.Time) ([]*cronplan.Expression, error) {
+	loc, err := location(s)
+
+	if err != nil {
+		return nil, err
+	} else if loc == time.UTC {
+		return exprs, nil
+	}
+
+	shifted := []*cronplan.Expression{}
+
+	for _, e := range exprs {
+		ee, err := cronplan.ShiftZone(e, loc, time.UTC, now)
+
+		if err != nil {
+			return nil, err
+		}
+
+		shifted = append(shifted, ee...)
+	}
+
+	return shifted, nil
+}
+
+func main() {
+	flags := parseFlags()
+	schedules := []*scan.Schedule{}
+	failed := false
+
+	for _, path := range flags.paths {
+		info, err := os.Stat(path)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var ss []*scan.Schedule
+
+		if info.IsDir() {
+			ss, err = scan.Dir(path)
+		} else {
+			ss, err = scan.File(path)
+		}
+
+		if err != nil {
+			log.Printf("failed to scan: %s", err)
+			failed = true
+		}
+
+		schedules = append(schedules, ss...)
+	}
+
+	if flags.output == "json" {
+		out := []*schedule{}
+
+		for _, s := range schedules {
+			o := &schedule{
+				File:       s.File,
+				Line:       s.Line,
+				Resource:   s.Resource,
+				Source:     s.Source,
+				Expression: s.Expression,
+				Timezone:   s.Timezone,
+			}
+
+			if err := validate(s); err != nil {
+				o.Error = err.Error()
+			} else if flags.check {
+				continue
+			}
+
+			out = append(out, o)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(out); err != nil {
+			log.Fatal(err)
+		}
+
+		if flags.check && (failed || len(out) > 0) {
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	now := time.Now()
+
+	for _, s := range schedules {
+		if flags.check {
+			if err := validate(s); err != nil {
+				fmt.Printf("%s:%d: %s: %s\n", s.File, s.Line, s.Resource, err)
+				failed = true
+			}
+
+			continue
+		}
+
+		exprs, note, err := parse(s)
+
+		if err == nil {
+			exprs, err = toUTC(s, exprs, now)
+		}
+
+		if err != nil {
+			log.Printf("%s:%d: %s: skipped: %s", s.File, s.Line, s.Resource, err)
+			continue
+		}
+
+		if note != "" {
+			log.Printf("%s:%d: %s: %s", s.File, s.Line, s.Resource, note)
+		}
+
+		for i, e := range exprs {
+			name := s.Resource
+
+			if len(exprs) > 1 {
+				name = fmt.Sprintf("%s.%d", name, i+1)
+			}
+
+			fmt.Printf("%s\t%s\n", name, e)
+		}
+	}
+
+	if flags.check && failed {
+		os.Exit(1)
+	}
+}
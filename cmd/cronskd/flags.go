@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,9 +14,12 @@ var (
 )
 
 type flags struct {
-	file  string
-	start string
-	end   string
+	file      string
+	start     string
+	end       string
+	tz        string
+	utcOffset string
+	output    string
 }
 
 func init() {
@@ -33,6 +37,9 @@ func parseFlags() *flags {
 	flags := &flags{}
 	flag.StringVar(&flags.start, "s", "", "start date (default: beginning of day)")
 	flag.StringVar(&flags.end, "e", "", "end date (default: end of day)")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
+	flag.StringVar(&flags.output, "o", "text", "output format (text, json, csv, tsv)")
 	showVersion := flag.Bool("version", false, "print version and exit")
 	flag.Parse()
 
@@ -40,6 +47,12 @@ func parseFlags() *flags {
 		printVersionAndExit()
 	}
 
+	switch flags.output {
+	case "text", "json", "csv", "tsv":
+	default:
+		log.Fatalf("invalid output format: %s", flags.output)
+	}
+
 	args := flag.Args()
 
 	if len(args) > 1 {
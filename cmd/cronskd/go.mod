@@ -6,9 +6,18 @@ toolchain go1.26.5
 
 replace github.com/winebarrel/cronplan/v2 => ../..
 
+replace github.com/winebarrel/cronplan/v2/internal/input => ../../internal/input
+
+replace github.com/winebarrel/cronplan/v2/kube => ../../kube
+
 require (
 	github.com/araddon/dateparse v0.0.0-20210429162001-6b43995a97de
 	github.com/winebarrel/cronplan/v2 v2.0.0-00010101000000-000000000000
+	github.com/winebarrel/cronplan/v2/internal/input v0.0.0-00010101000000-000000000000
+	github.com/winebarrel/cronplan/v2/kube v0.0.0-00010101000000-000000000000
 )
 
-require github.com/alecthomas/participle/v2 v2.1.4 // indirect
+require (
+	github.com/alecthomas/participle/v2 v2.1.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -1,95 +1,240 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"sort"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/araddon/dateparse"
-	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/awsexport"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+	"github.com/winebarrel/cronplan/v2/kube"
 )
 
 func init() {
 	log.SetFlags(0)
 }
 
+type exprNext struct {
+	name string
+	expr string
+	// tz is the time zone set to the schedule. It is empty if not set.
+	tz string
+	// loc is the time zone that the expression is evaluated in.
+	loc      *time.Location
+	next     time.Time
+	disabled bool
+}
+
+type occurrence struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Time       string `json:"time"`
+	Timezone   string `json:"timezone"`
+	Disabled   bool   `json:"disabled"`
+}
+
+func (ln *exprNext) occurrence(loc *time.Location) *occurrence {
+	tz := ln.loc.String()
+
+	// the local time zone has no name, so use the abbreviation
+	if ln.loc == time.Local {
+		tz, _ = ln.next.In(time.Local).Zone()
+	}
+
+	return &occurrence{
+		Name:       ln.name,
+		Expression: ln.expr,
+		Time:       ln.next.In(loc).Format(time.RFC3339),
+		Timezone:   tz,
+		Disabled:   ln.disabled,
+	}
+}
+
 func main() {
 	flags := parseFlags()
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
 
-	var scanner *bufio.Scanner
+	timeFormat := "Mon, 02 Jan 2006 15:04:05"
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		timeFormat += " MST"
+	}
 
-	if flags.file == "-" {
-		scanner = bufio.NewScanner(os.Stdin)
+	var start, end time.Time
+
+	if flags.start == "" {
+		now := time.Now().In(loc)
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
 	} else {
-		file, err := os.Open(flags.file)
+		start, err = dateparse.ParseIn(flags.start, loc)
 
 		if err != nil {
 			log.Fatal(err)
 		}
+	}
 
-		defer file.Close()
+	if flags.end == "" {
+		end = time.Date(start.Year(), start.Month(), start.Day(), 23, 59, 50, 0, start.Location())
+	} else {
+		end, err = dateparse.ParseIn(flags.end, loc)
 
-		scanner = bufio.NewScanner(file)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
-	type exprNext struct {
-		expr string
-		next time.Time
-	}
+	filename, src, err := input.ReadFile(flags.file)
 
-	schedule := []exprNext{}
+	if err != nil {
+		log.Fatalf("failed to read %s: %s", filename, err)
+	}
 
-	for scanner.Scan() {
-		expr := scanner.Text()
-		expr = strings.TrimSpace(expr)
-		expr = strings.TrimPrefix(expr, "cron(")
-		expr = strings.TrimSuffix(expr, ")")
+	schedule := []*exprNext{}
 
-		cron, err := cronplan.Parse(expr)
+	if kube.IsManifest(src) {
+		// Kubernetes CronJob manifests or the output of "kubectl get cronjobs -o yaml"
+		jobs, err := kube.Read(bytes.NewReader(src))
 
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		var start, end time.Time
-
-		if flags.start == "" {
-			now := time.Now()
-			start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		} else {
-			start, err = dateparse.ParseAny(flags.start)
+		for _, j := range jobs {
+			nexts, err := j.Between(start, end)
 
 			if err != nil {
-				log.Fatal(err)
+				log.Fatalf("%s: %s", j.FullName(), err)
+			}
+
+			jobLoc, _ := j.Location()
+
+			for _, n := range nexts {
+				schedule = append(schedule, &exprNext{name: j.FullName(), expr: j.Schedule, tz: j.TimeZone, loc: jobLoc, next: n, disabled: j.Suspend})
 			}
 		}
+	} else if input.IsAWSExport(src) {
+		// the output of "aws events list-rules" or "aws scheduler get-schedule"
+		schedules, err := awsexport.Read(bytes.NewReader(src))
 
-		if flags.end == "" {
-			end = time.Date(start.Year(), start.Month(), start.Day(), 23, 59, 50, 0, start.Location())
-		} else {
-			end, err = dateparse.ParseAny(flags.end)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, s := range schedules {
+			nexts, err := s.Between(start, end)
 
 			if err != nil {
-				log.Fatal(err)
+				log.Fatalf("%s: %s", s.Name, err)
 			}
+
+			sLoc, _ := s.Location()
+
+			for _, n := range nexts {
+				schedule = append(schedule, &exprNext{name: s.Name, expr: s.ScheduleExpression, tz: s.ScheduleExpressionTimezone, loc: sLoc, next: n, disabled: !s.Enabled()})
+			}
+		}
+	} else {
+		entries, err := input.Parse(filename, src)
+
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		nexts := cron.Between(start, end)
+		for _, e := range entries {
+			nexts := e.Cron.Between(zone.In(start, evalLoc), zone.In(end, evalLoc))
 
-		for _, n := range nexts {
-			schedule = append(schedule, exprNext{expr: expr, next: n})
+			for _, n := range nexts {
+				schedule = append(schedule, &exprNext{name: e.Name, expr: e.Expr, loc: n.Location(), next: n})
+			}
 		}
 	}
 
-	sort.Slice(schedule, func(i, j int) bool {
+	sort.SliceStable(schedule, func(i, j int) bool {
 		return schedule[i].next.Before(schedule[j].next)
 	})
 
+	switch flags.output {
+	case "json":
+		out := []*occurrence{}
+
+		for _, ln := range schedule {
+			out = append(out, ln.occurrence(loc))
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(out); err != nil {
+			log.Fatal(err)
+		}
+	case "csv", "tsv":
+		w := csv.NewWriter(os.Stdout)
+
+		if flags.output == "tsv" {
+			w.Comma = '\t'
+		}
+
+		records := [][]string{{"name", "expression", "time", "timezone", "disabled"}}
+
+		for _, ln := range schedule {
+			o := ln.occurrence(loc)
+			records = append(records, []string{o.Name, o.Expression, o.Time, o.Timezone, strconv.FormatBool(o.Disabled)})
+		}
+
+		if err := w.WriteAll(records); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		printText(schedule, loc, timeFormat)
+	}
+}
+
+func printText(schedule []*exprNext, loc *time.Location, timeFormat string) {
+	enabled := []*exprNext{}
+	disabled := []*exprNext{}
+
 	for _, ln := range schedule {
-		fmt.Printf("%s\t%s\n", ln.next.Format("Mon, 02 Jan 2006 15:04:05"), ln.expr)
+		if ln.disabled {
+			disabled = append(disabled, ln)
+		} else {
+			enabled = append(enabled, ln)
+		}
+	}
+
+	for i, sched := range [][]*exprNext{enabled, disabled} {
+		if i == 1 {
+			if len(sched) == 0 {
+				break
+			}
+
+			fmt.Println()
+			fmt.Println("Disabled:")
+		}
+
+		for _, ln := range sched {
+			expr := ln.expr
+
+			if ln.tz != "" {
+				expr += " " + ln.tz
+			}
+
+			if ln.name != "" {
+				fmt.Printf("%s\t%s\t%s\n", ln.next.In(loc).Format(timeFormat), ln.name, expr)
+			} else {
+				fmt.Printf("%s\t%s\n", ln.next.In(loc).Format(timeFormat), expr)
+			}
+		}
 	}
 }
@@ -13,10 +13,12 @@ var (
 )
 
 type flags struct {
-	from   string
-	period string
-	h      int
-	input  string
+	from      string
+	period    string
+	h         int
+	tz        string
+	utcOffset string
+	input     string
 }
 
 func init() {
@@ -35,7 +37,9 @@ func parseFlags() *flags {
 
 	flag.StringVar(&flags.from, "f", "", "from date (default current date)")
 	flag.StringVar(&flags.period, "p", "1d", "period")
-	flag.IntVar(&flags.h, "h", 0, "hour to add")
+	flag.IntVar(&flags.h, "h", 0, "hour to add (deprecated: use '-tz' or '-utc-offset')")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
 	showVersion := flag.Bool("version", false, "print version and exit")
 	flag.Parse()
 
@@ -43,6 +47,10 @@ func parseFlags() *flags {
 		printVersionAndExit()
 	}
 
+	if flags.h != 0 && (flags.tz != "" || flags.utcOffset != "") {
+		log.Fatal("'-h' cannot be used with '-tz' or '-utc-offset'")
+	}
+
 	args := flag.Args()
 
 	if len(args) > 1 {
@@ -1,19 +1,19 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	_ "embed"
-	"io"
 	"log"
 	"os"
-	"regexp"
-	"strings"
 	"text/template"
 	"time"
 
 	"github.com/araddon/dateparse"
 	"github.com/k1LoW/duration"
-	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/awsexport"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+	"github.com/winebarrel/cronplan/v2/kube"
 )
 
 //go:embed timeline.html.tmpl
@@ -32,14 +32,19 @@ type Row struct {
 func main() {
 	flags := parseFlags()
 
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
 	var from time.Time
-	var err error
 
 	if flags.from == "" {
-		from = time.Now()
-		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+		from = time.Now().In(loc)
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
 	} else {
-		from, err = dateparse.ParseAny(flags.from)
+		from, err = dateparse.ParseIn(flags.from, loc)
 
 		if err != nil {
 			log.Fatalf("failed to parse from date: %s", err)
@@ -53,61 +58,115 @@ func main() {
 	}
 
 	to := from.Add(period)
-	var file io.ReadCloser
+	filename, src, err := input.ReadFile(flags.input)
 
-	if flags.input == "" {
-		file = os.Stdin
-	} else {
-		file, err = os.OpenFile(flags.input, os.O_RDONLY, 0)
+	if err != nil {
+		log.Fatalf("failed to read %s: %s", filename, err)
+	}
+
+	schedule := map[string]*Row{}
+
+	if kube.IsManifest(src) {
+		// Kubernetes CronJob manifests or the output of "kubectl get cronjobs -o yaml"
+		jobs, err := kube.Read(bytes.NewReader(src))
 
 		if err != nil {
-			log.Fatalf("failed to open %s: %s", flags.input, err)
+			log.Fatal(err)
 		}
-	}
 
-	defer file.Close()
+		for _, j := range jobs {
+			ts, err := j.Between(from, to)
 
-	scanner := bufio.NewScanner(file)
-	r := regexp.MustCompile(`\s+`)
+			if err != nil {
+				log.Fatalf("failed to evaluate schedule: %s/%s: %s", j.FullName(), j.Schedule, err)
+			}
 
-	schedule := map[string]*Row{}
+			for i, t := range ts {
+				ts[i] = t.Add(time.Duration(flags.h) * time.Hour).In(loc)
+			}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+			name := j.FullName()
+			expr := j.Schedule
 
-		if line == "" {
-			continue
-		}
+			if j.TimeZone != "" {
+				expr += " " + j.TimeZone
+			}
 
-		fields := r.Split(line, 2)
+			// suspended CronJobs are shown as separate rows
+			if j.Suspend {
+				name += " (suspended)"
+			}
 
-		if len(fields) < 2 {
-			log.Fatalf("too few fields: %s", line)
+			schedule[name] = &Row{
+				Expr:  expr,
+				Times: ts,
+			}
+		}
+	} else if input.IsAWSExport(src) {
+		// the output of "aws events list-rules" or "aws scheduler get-schedule"
+		schedules, err := awsexport.Read(bytes.NewReader(src))
+
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		name := fields[0]
-		expr := fields[1]
-		cron, err := cronplan.Parse(expr)
+		for _, s := range schedules {
+			ts, err := s.Between(from, to)
+
+			if err != nil {
+				log.Fatalf("failed to evaluate schedule expression: %s/%s: %s", s.Name, s.ScheduleExpression, err)
+			}
+
+			for i, t := range ts {
+				ts[i] = t.Add(time.Duration(flags.h) * time.Hour).In(loc)
+			}
+
+			name := s.Name
+			expr := s.ScheduleExpression
+
+			if s.ScheduleExpressionTimezone != "" {
+				expr += " " + s.ScheduleExpressionTimezone
+			}
+
+			// disabled rules are shown as separate rows
+			if !s.Enabled() {
+				name += " (disabled)"
+			}
+
+			schedule[name] = &Row{
+				Expr:  expr,
+				Times: ts,
+			}
+		}
+	} else {
+		entries, err := input.Parse(filename, src)
 
 		if err != nil {
-			log.Fatalf("failed to parse cron expr: %s/%s: %s", name, expr, err)
+			log.Fatal(err)
 		}
 
-		ts := cron.Between(from, to)
+		for _, e := range entries {
+			// an unnamed expression is shown by itself
+			name := e.Name
+			expr := e.Expr
+
+			if name == "" {
+				name = expr
+			}
 
-		if flags.h != 0 {
+			ts := e.Cron.Between(zone.In(from, evalLoc), zone.In(to, evalLoc))
 			newts := make([]time.Time, 0, len(ts))
 
 			for _, t := range ts {
-				newts = append(newts, t.Add(time.Duration(flags.h)*time.Hour))
+				newts = append(newts, t.Add(time.Duration(flags.h)*time.Hour).In(loc))
 			}
 
 			ts = newts
-		}
 
-		schedule[name] = &Row{
-			Expr:  expr,
-			Times: ts,
+			schedule[name] = &Row{
+				Expr:  expr,
+				Times: ts,
+			}
 		}
 	}
 
@@ -124,9 +183,16 @@ func main() {
 		},
 	}).Parse(timelineTmpl))
 
+	var tz string
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		tz = loc.String()
+	}
+
 	err = t.Execute(os.Stdout, map[string]interface{}{
 		"schedule": schedule,
 		"offset":   flags.h,
+		"tz":       tz,
 	})
 
 	if err != nil {
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	version string
+)
+
+type flags struct {
+	h         int
+	tz        string
+	utcOffset string
+	t         string
+	end       string
+	input     string
+}
+
+func init() {
+	cmdLine := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+
+	cmdLine.Usage = func() {
+		fmt.Fprintf(cmdLine.Output(), "Usage: %s [OPTION] DATE [FILE]\n", cmdLine.Name())
+		cmdLine.PrintDefaults()
+	}
+
+	flag.CommandLine = cmdLine
+}
+
+func parseFlags() *flags {
+	flags := &flags{}
+	flag.IntVar(&flags.h, "h", 0, "hour to add (deprecated: use '-tz' or '-utc-offset')")
+	flag.StringVar(&flags.tz, "tz", "", "time zone to parse and display dates, evaluating expressions in UTC (e.g. 'Asia/Tokyo')")
+	flag.StringVar(&flags.utcOffset, "utc-offset", "", "UTC offset to parse and display dates, evaluating expressions in UTC (e.g. '+05:30')")
+	flag.StringVar(&flags.end, "e", "", "end date (list jobs running between DATE and end date)")
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		printVersionAndExit()
+	}
+
+	if flags.h != 0 && (flags.tz != "" || flags.utcOffset != "") {
+		log.Fatal("'-h' cannot be used with '-tz' or '-utc-offset'")
+	}
+
+	args := flag.Args()
+
+	if len(args) == 0 {
+		printUsageAndExit()
+	} else if len(args) > 2 {
+		log.Fatal("too many arguments")
+	}
+
+	flags.t = strings.TrimSpace(args[0])
+
+	if len(args) == 2 {
+		flags.input = args[1]
+	}
+
+	return flags
+}
+
+func printVersionAndExit() {
+	v := version
+
+	if v == "" {
+		v = "<nil>"
+	}
+
+	fmt.Fprintln(flag.CommandLine.Output(), v)
+	os.Exit(0)
+}
+
+func printUsageAndExit() {
+	flag.CommandLine.Usage()
+	os.Exit(0)
+}
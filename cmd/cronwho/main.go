@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	flags := parseFlags()
+	offset := time.Duration(flags.h) * time.Hour
+	loc, evalLoc, err := zone.Load(flags.tz, flags.utcOffset)
+
+	if err != nil {
+		log.Fatalf("failed to load time zone: %s", err)
+	}
+
+	timeFormat := "Mon, 02 Jan 2006 15:04:05"
+
+	if flags.tz != "" || flags.utcOffset != "" {
+		timeFormat += " MST"
+	}
+
+	t, err := dateparse.ParseIn(flags.t, loc)
+
+	if err != nil {
+		log.Fatalf("failed to parse date: %s", err)
+	}
+
+	t = zone.In(t, evalLoc).Add(offset)
+	entries, err := input.Load(flags.input)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	set := cronplan.NewExpressionSet()
+	exprs := map[string]string{}
+
+	for _, e := range entries {
+		// an unnamed expression is listed by itself
+		name := e.Name
+
+		if name == "" {
+			name = e.Expr
+		}
+
+		set.Add(name, e.Cron)
+		exprs[name] = e.Expr
+	}
+
+	if set.Len() == 0 {
+		log.Fatal("input is empty")
+	}
+
+	found := false
+
+	if flags.end == "" {
+		for _, name := range set.At(t) {
+			fmt.Printf("%s\t%s\n", name, exprs[name])
+			found = true
+		}
+	} else {
+		end, err := dateparse.ParseIn(flags.end, loc)
+
+		if err != nil {
+			log.Fatalf("failed to parse end date: %s", err)
+		}
+
+		end = zone.In(end, evalLoc).Add(offset)
+
+		for _, occ := range set.Between(t, end) {
+			for _, name := range occ.Names {
+				fmt.Printf("%s\t%s\t%s\n", occ.Time.Add(-offset).In(loc).Format(timeFormat), name, exprs[name])
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,386 @@
+// Package crontab reads classic crontab files and converts the entries to EventBridge expressions.
+package crontab
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+var (
+	envRegexp      = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+	invalidNameRun = regexp.MustCompile(`[^._A-Za-z0-9]+`)
+)
+
+// macros are the "@" schedules in EventBridge form.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 ? *",
+	"@annually": "0 0 1 1 ? *",
+	"@monthly":  "0 0 1 * ? *",
+	"@weekly":   "0 0 ? * SUN *",
+	"@daily":    "0 0 * * ? *",
+	"@midnight": "0 0 * * ? *",
+	"@hourly":   "0 * * * ? *",
+}
+
+// Entry is a job line of a crontab.
+type Entry struct {
+	Line int
+	// Schedule is the schedule as written: five fields or a macro (e.g. "@daily").
+	Schedule string
+	// User is the user column of a system crontab (e.g. /etc/crontab). It is empty for a user crontab.
+	User    string
+	Command string
+	// Env holds the environment variables set before the entry.
+	Env map[string]string
+	// Timezone is the value of CRON_TZ set before the entry. It is empty if not set.
+	Timezone string
+	// Expressions are the EventBridge expressions that fire at the same times as the schedule.
+	Expressions []*cronplan.Expression
+}
+
+// Name returns a hint for the EventBridge rule name derived from the command,
+// e.g. "backup.sh-full" for "/usr/local/bin/backup.sh --full".
+func (e *Entry) Name() string {
+	cmd := strings.TrimSpace(e.Command)
+	first, rest, _ := strings.Cut(cmd, " ")
+	name := path.Base(first) + " " + rest
+	name = strings.Trim(invalidNameRun.ReplaceAllString(name, "-"), "-")
+
+	if len(name) > 64 {
+		name = strings.TrimRight(name[:64], "-")
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("line-%d", e.Line)
+	}
+
+	return name
+}
+
+// Error is an error of a crontab line.
+type Error struct {
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Read reads a crontab. If system is true, the entries have the user column like /etc/crontab and /etc/cron.d.
+// Comments, blank lines and environment variable lines are skipped, and CRON_TZ is set to the following entries.
+// A line that cannot be parsed or converted does not stop the reading: the *Error values are joined
+// and returned with the entries.
+func Read(r io.Reader, system bool) ([]*Entry, error) {
+	scanner := bufio.NewScanner(r)
+	lineno := 0
+	env := map[string]string{}
+	entries := []*Entry{}
+	errs := []error{}
+
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := envRegexp.FindStringSubmatch(line); m != nil {
+			// the map is shared by the entries, so copy it on write
+			newEnv := make(map[string]string, len(env)+1)
+
+			for k, v := range env {
+				newEnv[k] = v
+			}
+
+			newEnv[m[1]] = unquote(m[2])
+			env = newEnv
+			continue
+		}
+
+		entry, err := parseEntry(line, system)
+
+		if err != nil {
+			errs = append(errs, &Error{Line: lineno, Err: err})
+			continue
+		}
+
+		entry.Line = lineno
+		entry.Env = env
+		entry.Timezone = env["CRON_TZ"]
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// cutFields cuts n whitespace-separated fields from the line and returns them with the rest of the line.
+func cutFields(line string, n int) ([]string, string) {
+	fields := make([]string, 0, n)
+
+	for len(fields) < n {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+
+		if line == "" {
+			break
+		}
+
+		i := strings.IndexFunc(line, unicode.IsSpace)
+
+		if i < 0 {
+			i = len(line)
+		}
+
+		fields = append(fields, line[:i])
+		line = line[i:]
+	}
+
+	return fields, strings.TrimSpace(line)
+}
+
+func parseEntry(line string, system bool) (*Entry, error) {
+	n := 5
+
+	if strings.HasPrefix(line, "@") {
+		n = 1
+	}
+
+	if system {
+		n++
+	}
+
+	fields, command := cutFields(line, n)
+
+	if len(fields) < n || command == "" {
+		return nil, fmt.Errorf("too few fields: %s", line)
+	}
+
+	entry := &Entry{Command: command}
+
+	if system {
+		entry.User = fields[n-1]
+		fields = fields[:n-1]
+	}
+
+	entry.Schedule = strings.Join(fields, " ")
+	exprs, err := Convert(entry.Schedule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Expressions = exprs
+	return entry, nil
+}
+
+// Convert converts a crontab schedule (five fields or a macro) to EventBridge expressions.
+// In a crontab, if both day-of-month and day-of-week are restricted (i.e. do not start with "*"),
+// the job runs on the days matching either of them. Since EventBridge requires either of them to be "?",
+// such a schedule is split into two expressions.
+func Convert(schedule string) ([]*cronplan.Expression, error) {
+	return convert(schedule, false)
+}
+
+// ConvertKubernetes converts a Kubernetes CronJob schedule to EventBridge expressions and returns them
+// with the time zone of the "CRON_TZ=" or "TZ=" prefix (empty if not set).
+// The dialect is that of the CronJob controller: five fields or a macro, day-of-week 0-6 and "?" as "*".
+// Unlike a crontab, day-of-month and day-of-week are combined with OR unless either of them is "*" or "?",
+// e.g. "0 0 */2 * MON" runs on the odd days and on Mondays.
+func ConvertKubernetes(schedule string) ([]*cronplan.Expression, string, error) {
+	tz := ""
+
+	if strings.HasPrefix(schedule, "CRON_TZ=") || strings.HasPrefix(schedule, "TZ=") {
+		var prefix string
+		prefix, schedule, _ = strings.Cut(strings.TrimSpace(schedule), " ")
+		_, tz, _ = strings.Cut(prefix, "=")
+		schedule = strings.TrimSpace(schedule)
+	}
+
+	exprs, err := convert(schedule, true)
+	return exprs, tz, err
+}
+
+func convert(schedule string, kubernetes bool) ([]*cronplan.Expression, error) {
+	var exps []string
+
+	if strings.HasPrefix(schedule, "@") {
+		exp, ok := macros[strings.ToLower(schedule)]
+
+		if !ok {
+			return nil, fmt.Errorf("'%s' cannot be converted to EventBridge expressions", schedule)
+		}
+
+		exps = []string{exp}
+	} else {
+		fields := strings.Fields(schedule)
+
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("'%s' must have five fields", schedule)
+		}
+
+		maxWday := 7
+
+		if kubernetes {
+			maxWday = 6
+
+			for i, f := range fields {
+				if f == "?" {
+					fields[i] = "*"
+				}
+			}
+
+			// "L", "W" and "#" are EventBridge extensions
+			if strings.ContainsAny(fields[2], "LW#") {
+				return nil, fmt.Errorf("invalid day-of-month in '%s': '%s'", schedule, fields[2])
+			}
+		}
+
+		minute, hour, dom, month := fields[0], fields[1], fields[2], fields[3]
+		dow, err := convertDayOfWeek(fields[4], maxWday)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid day-of-week in '%s': %w", schedule, err)
+		}
+
+		switch {
+		case dom == "*" && dow == "*":
+			exps = []string{fmt.Sprintf("%s %s * %s ? *", minute, hour, month)}
+		case dom == "*":
+			exps = []string{fmt.Sprintf("%s %s ? %s %s *", minute, hour, month, dow)}
+		case dow == "*":
+			exps = []string{fmt.Sprintf("%s %s %s %s ? *", minute, hour, dom, month)}
+		case !kubernetes && (strings.HasPrefix(dom, "*") || strings.HasPrefix(fields[4], "*")):
+			// e.g. "*/2" and "MON" run on the odd days that are Mondays
+			return nil, fmt.Errorf("'%s' cannot be converted to EventBridge expressions: day-of-month and day-of-week are combined with AND", schedule)
+		default:
+			exps = []string{
+				fmt.Sprintf("%s %s %s %s ? *", minute, hour, dom, month),
+				fmt.Sprintf("%s %s ? %s %s *", minute, hour, month, dow),
+			}
+		}
+	}
+
+	exprs := make([]*cronplan.Expression, 0, len(exps))
+
+	for _, exp := range exps {
+		cron, err := cronplan.Parse(exp)
+
+		if err != nil {
+			return nil, fmt.Errorf("'%s' cannot be converted to EventBridge expressions: %w", schedule, err)
+		}
+
+		exprs = append(exprs, cron)
+	}
+
+	return exprs, nil
+}
+
+// convertDayOfWeek converts the day-of-week field from numbers up to maxWday (0 and 7 are Sunday) to names,
+// expanding the items that include 7 or a single day with a step, which run until Sunday in a crontab.
+func convertDayOfWeek(field string, maxWday int) (string, error) {
+	items := []string{}
+
+	for _, item := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(item, "/")
+
+		if rng == "*" {
+			items = append(items, item)
+			continue
+		}
+
+		first, last, isRange := strings.Cut(rng, "-")
+		start, err := weekday(first, maxWday)
+
+		if err != nil {
+			return "", err
+		}
+
+		end := start
+
+		if isRange {
+			end, err = weekday(last, maxWday)
+		} else if hasStep {
+			end = maxWday
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		n := 1
+
+		if hasStep {
+			n, err = strconv.Atoi(step)
+
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid step '%s'", step)
+			}
+		}
+
+		if start > end {
+			return "", fmt.Errorf("invalid range '%s'", rng)
+		}
+
+		switch {
+		case !isRange && !hasStep:
+			items = append(items, util.ShortWeekdayNames[start%7])
+		case end < 7:
+			s := util.ShortWeekdayNames[start] + "-" + util.ShortWeekdayNames[end]
+
+			if hasStep {
+				s += "/" + step
+			}
+
+			items = append(items, s)
+		default:
+			for d := start; d <= end; d += n {
+				// 0 and 7 are both Sunday
+				if d != 7 || start > 0 {
+					items = append(items, util.ShortWeekdayNames[d%7])
+				}
+			}
+		}
+	}
+
+	return strings.Join(items, ","), nil
+}
+
+func weekday(s string, maxWday int) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > maxWday {
+			return 0, fmt.Errorf("day-of-week must be 0-%d (value=%d)", maxWday, n)
+		}
+
+		return n, nil
+	}
+
+	wday, err := util.CastWeekday(s)
+	return int(wday), err
+}
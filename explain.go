@@ -0,0 +1,278 @@
+package cronplan
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+type Explanation struct {
+	Time   time.Time
+	Match  bool
+	Fields []*FieldExplanation
+}
+
+type FieldExplanation struct {
+	Field string
+	Expr  string
+	// Value is the value of the field at the time.
+	Value string
+	Match bool
+	// Exp is the sub-expression that accepted the value, and Kind is its kind
+	// (e.g. "range", "step", "nearest weekday", "nth day of week").
+	Exp  string
+	Kind string
+	// Nearest is the nearest value allowed by the field when the value is rejected.
+	// It is empty if the field allows no value around the time.
+	Nearest string
+}
+
+type explainable interface {
+	Match(t time.Time) bool
+	String() string
+}
+
+func kindOf(e explainable) string {
+	switch e := e.(type) {
+	case *MinuteExp:
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	case *HourExp:
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	case *DayOfMonthExp:
+		if e.NearestWeekday != nil {
+			return "nearest weekday"
+		} else if e.LastWeekday != nil {
+			return "last weekday of month"
+		} else if e.Last != nil {
+			if e.Last.Int() > 0 {
+				return "last day of month with offset"
+			}
+			return "last day of month"
+		}
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	case *MonthExp:
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	case *DayOfWeekExp:
+		if e.Nth != nil {
+			return "nth day of week"
+		} else if e.Last != nil {
+			if e.Last.Wday == nil {
+				return "last (same as SAT)"
+			}
+			return "last day of week"
+		}
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	case *YearExp:
+		return basicKind(e.Wildcard, e.Range != nil, e.Bottom)
+	}
+
+	panic("must not happen")
+}
+
+func basicKind(wildcard bool, isRange bool, bottom *int) string {
+	var kind string
+
+	if wildcard {
+		kind = "wildcard"
+	} else if isRange {
+		kind = "range"
+	} else {
+		kind = "number"
+	}
+
+	if bottom != nil {
+		kind += " step"
+	}
+
+	return kind
+}
+
+func explainField(field string, expr fmt.Stringer, any bool, exps []explainable, t time.Time, value func(time.Time) string, candidates []time.Time) *FieldExplanation {
+	fe := &FieldExplanation{
+		Field: field,
+		Expr:  expr.String(),
+		Value: value(t),
+	}
+
+	if any {
+		fe.Match = true
+		fe.Exp = "?"
+		fe.Kind = "any"
+		return fe
+	}
+
+	for _, e := range exps {
+		if e.Match(t) {
+			fe.Match = true
+			fe.Exp = e.String()
+			fe.Kind = kindOf(e)
+			return fe
+		}
+	}
+
+	var nearest time.Time
+	var dist time.Duration
+
+	for _, c := range candidates {
+		matched := false
+
+		for _, e := range exps {
+			if e.Match(c) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		d := c.Sub(t)
+
+		if d < 0 {
+			d = -d
+		}
+
+		if nearest.IsZero() || d < dist || (d == dist && c.After(nearest)) {
+			nearest = c
+			dist = d
+		}
+	}
+
+	if !nearest.IsZero() {
+		fe.Nearest = value(nearest)
+	}
+
+	return fe
+}
+
+func (v *Expression) Explain(t time.Time) *Explanation {
+	loc := t.Location()
+	y, mon, d, h, m := t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()
+	fields := make([]*FieldExplanation, 0, 6)
+
+	// minute
+	{
+		exps := make([]explainable, 0, len(v.Minute.Exps))
+
+		for _, e := range v.Minute.Exps {
+			exps = append(exps, e)
+		}
+
+		candidates := make([]time.Time, 0, 60)
+
+		for minute := 0; minute <= 59; minute++ {
+			candidates = append(candidates, time.Date(y, mon, d, h, minute, 0, 0, loc))
+		}
+
+		fields = append(fields, explainField("minute", v.Minute, false, exps, t, func(t time.Time) string {
+			return strconv.Itoa(t.Minute())
+		}, candidates))
+	}
+
+	// hour
+	{
+		exps := make([]explainable, 0, len(v.Hour.Exps))
+
+		for _, e := range v.Hour.Exps {
+			exps = append(exps, e)
+		}
+
+		candidates := make([]time.Time, 0, 24)
+
+		for hour := 0; hour <= 23; hour++ {
+			candidates = append(candidates, time.Date(y, mon, d, hour, m, 0, 0, loc))
+		}
+
+		fields = append(fields, explainField("hour", v.Hour, false, exps, t, func(t time.Time) string {
+			return strconv.Itoa(t.Hour())
+		}, candidates))
+	}
+
+	days := make([]time.Time, 0, 31)
+
+	for day := 1; day <= util.LastOfMonth(t); day++ {
+		days = append(days, time.Date(y, mon, day, h, m, 0, 0, loc))
+	}
+
+	// day-of-month
+	{
+		exps := make([]explainable, 0, len(v.DayOfMonth.Exps))
+
+		for _, e := range v.DayOfMonth.Exps {
+			exps = append(exps, e)
+		}
+
+		fields = append(fields, explainField("day-of-month", v.DayOfMonth, v.DayOfMonth.Any, exps, t, func(t time.Time) string {
+			return strconv.Itoa(t.Day())
+		}, days))
+	}
+
+	// month
+	{
+		exps := make([]explainable, 0, len(v.Month.Exps))
+
+		for _, e := range v.Month.Exps {
+			exps = append(exps, e)
+		}
+
+		candidates := make([]time.Time, 0, 12)
+
+		for month := time.January; month <= time.December; month++ {
+			candidates = append(candidates, time.Date(y, month, 1, h, m, 0, 0, loc))
+		}
+
+		fields = append(fields, explainField("month", v.Month, false, exps, t, func(t time.Time) string {
+			return util.ShortMonthNames[t.Month()-1]
+		}, candidates))
+	}
+
+	// day-of-week
+	{
+		exps := make([]explainable, 0, len(v.DayOfWeek.Exps))
+
+		for _, e := range v.DayOfWeek.Exps {
+			exps = append(exps, e)
+		}
+
+		fields = append(fields, explainField("day-of-week", v.DayOfWeek, v.DayOfWeek.Any, exps, t, func(t time.Time) string {
+			return fmt.Sprintf("%s (day %d)", util.ShortWeekdayNames[t.Weekday()], t.Day())
+		}, days))
+	}
+
+	// year
+	{
+		exps := make([]explainable, 0, len(v.Year.Exps))
+
+		for _, e := range v.Year.Exps {
+			exps = append(exps, e)
+		}
+
+		candidates := make([]time.Time, 0, maxYear-minYear+1)
+
+		for year := minYear; year <= maxYear; year++ {
+			candidates = append(candidates, time.Date(year, 1, 1, h, m, 0, 0, loc))
+		}
+
+		fields = append(fields, explainField("year", v.Year, false, exps, t, func(t time.Time) string {
+			return strconv.Itoa(t.Year())
+		}, candidates))
+	}
+
+	match := true
+
+	for _, f := range fields {
+		if !f.Match {
+			match = false
+			break
+		}
+	}
+
+	return &Explanation{
+		Time:   t,
+		Match:  match,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,237 @@
+// Package gen generates random valid expressions for property-based testing.
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+const (
+	DefaultEdgeBias = 0.2
+	DefaultMaxItems = 3
+)
+
+type Options struct {
+	// EdgeBias is the probability of choosing an edge case, such as a bound of a field,
+	// a wrapping range, a zero step, "L-30", "31W", "LW" and "#5".
+	EdgeBias float64
+	// MaxItems is the maximum number of the comma-separated items of a field.
+	MaxItems int
+}
+
+type Generator struct {
+	rand    *rand.Rand
+	options Options
+}
+
+// NewGenerator returns a generator seeded with seed.
+// If options is nil, the default options are used.
+func NewGenerator(seed int64, options *Options) *Generator {
+	g := &Generator{
+		rand: rand.New(rand.NewSource(seed)),
+		options: Options{
+			EdgeBias: DefaultEdgeBias,
+			MaxItems: DefaultMaxItems,
+		},
+	}
+
+	if options != nil {
+		g.options = *options
+
+		if g.options.MaxItems < 1 {
+			g.options.MaxItems = 1
+		}
+	}
+
+	return g
+}
+
+// Next returns a random valid expression.
+func (g *Generator) Next() *cronplan.Expression {
+	exp := g.NextString()
+	cron, err := cronplan.Parse(exp)
+
+	if err != nil {
+		panic(fmt.Sprintf("generated an invalid expression '%s': %s", exp, err))
+	}
+
+	return cron
+}
+
+// NextString returns a random valid expression as a string.
+func (g *Generator) NextString() string {
+	var dom, dow string
+
+	if g.rand.Intn(2) == 0 {
+		dom = g.dayOfMonth()
+		dow = "?"
+	} else {
+		dom = "?"
+		dow = g.dayOfWeek()
+	}
+
+	return strings.Join([]string{g.minute(), g.hour(), dom, g.month(), dow, g.year()}, " ")
+}
+
+func (g *Generator) edge() bool {
+	return g.rand.Float64() < g.options.EdgeBias
+}
+
+// between returns a number in min-max, biased toward the bounds.
+func (g *Generator) between(min int, max int) int {
+	if g.edge() {
+		if g.rand.Intn(2) == 0 {
+			return min
+		}
+
+		return max
+	}
+
+	return min + g.rand.Intn(max-min+1)
+}
+
+func (g *Generator) step(max int) string {
+	if g.rand.Intn(3) != 0 {
+		return ""
+	}
+
+	if g.edge() {
+		return fmt.Sprintf("/%d", []int{0, 1, max}[g.rand.Intn(3)])
+	}
+
+	return fmt.Sprintf("/%d", 1+g.rand.Intn(max))
+}
+
+// basic returns "*", a number, or a range (possibly wrapping), with an optional step.
+func (g *Generator) basic(min int, max int, name func(int) string) string {
+	var s string
+
+	switch g.rand.Intn(3) {
+	case 0:
+		s = "*"
+	case 1:
+		s = name(g.between(min, max))
+	default:
+		start := g.between(min, max)
+		var end int
+
+		if g.edge() {
+			// wrapping range
+			end = min + g.rand.Intn(start-min+1)
+		} else {
+			end = start + g.rand.Intn(max-start+1)
+		}
+
+		s = name(start) + "-" + name(end)
+	}
+
+	return s + g.step(max-min+1)
+}
+
+func (g *Generator) list(item func() string) string {
+	n := 1 + g.rand.Intn(g.options.MaxItems)
+	items := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		items = append(items, item())
+	}
+
+	return strings.Join(items, ",")
+}
+
+func (g *Generator) minute() string {
+	return g.list(func() string { return g.basic(0, 59, itoa) })
+}
+
+func (g *Generator) hour() string {
+	return g.list(func() string { return g.basic(0, 23, itoa) })
+}
+
+func (g *Generator) dayOfMonth() string {
+	return g.list(func() string {
+		switch g.rand.Intn(6) {
+		case 0:
+			if g.edge() {
+				return "L-30"
+			}
+
+			return []string{"L", fmt.Sprintf("L-%d", g.between(1, 30))}[g.rand.Intn(2)]
+		case 1:
+			if g.edge() {
+				return []string{"1W", "31W", "LW"}[g.rand.Intn(3)]
+			}
+
+			return fmt.Sprintf("%dW", g.between(1, 31))
+		case 2:
+			return "LW"
+		default:
+			return g.basic(1, 31, itoa)
+		}
+	})
+}
+
+func (g *Generator) month() string {
+	return g.list(func() string {
+		if g.rand.Intn(2) == 0 {
+			return g.basic(1, 12, itoa)
+		}
+
+		return g.basic(1, 12, func(i int) string { return util.ShortMonthNames[i-1] })
+	})
+}
+
+func (g *Generator) dayOfWeek() string {
+	return g.list(func() string {
+		name := itoa
+
+		if g.rand.Intn(2) == 0 {
+			name = func(i int) string { return util.ShortWeekdayNames[i-1] }
+		}
+
+		switch g.rand.Intn(5) {
+		case 0:
+			nth := 1 + g.rand.Intn(5)
+
+			if g.edge() {
+				nth = 5
+			}
+
+			return fmt.Sprintf("%s#%d", name(g.between(1, 7)), nth)
+		case 1:
+			if g.rand.Intn(4) == 0 {
+				return "L"
+			}
+
+			return name(g.between(1, 7)) + "L"
+		default:
+			return g.basic(1, 7, name)
+		}
+	})
+}
+
+func (g *Generator) year() string {
+	if g.rand.Intn(2) == 0 {
+		return "*"
+	}
+
+	return g.list(func() string { return g.basic(1970, 2199, itoa) })
+}
+
+func itoa(i int) string {
+	return fmt.Sprint(i)
+}
+
+// Expression is an expression that implements quick.Generator.
+type Expression struct {
+	*cronplan.Expression
+}
+
+func (Expression) Generate(rand *rand.Rand, size int) reflect.Value {
+	g := NewGenerator(rand.Int63(), nil)
+	return reflect.ValueOf(Expression{g.Next()})
+}
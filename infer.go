@@ -0,0 +1,213 @@
+package cronplan
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+const maxInferenceExtra = 100
+
+// Inference is an expression inferred from example times.
+type Inference struct {
+	Expr *Expression
+	// Fit is the ratio of the examples to the runs of Expr between the first and the last examples.
+	// It is 1 if Expr runs exactly at the examples.
+	Fit float64
+	// Runs is the number of the runs of Expr between the first and the last examples.
+	Runs int
+	// Extra holds the runs that are not in the examples (at most 100).
+	Extra []time.Time
+}
+
+// Infer returns the expressions that cover all the example times, ranked by how well they fit.
+// The times are truncated to minutes and evaluated in the time zone of the first one.
+func Infer(times []time.Time) ([]*Inference, error) {
+	if len(times) == 0 {
+		return nil, errors.New("no example times")
+	}
+
+	loc := times[0].Location()
+	examples := map[time.Time]bool{}
+	sorted := []time.Time{}
+
+	for _, t := range times {
+		t = t.In(loc).Truncate(time.Minute)
+
+		if !examples[t] {
+			examples[t] = true
+			sorted = append(sorted, t)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	first := sorted[0]
+	last := sorted[len(sorted)-1]
+
+	if first.Year() < minYear || maxYear < last.Year() {
+		return nil, fmt.Errorf("example times must be in %d-%d", minYear, maxYear)
+	}
+
+	values := func(f func(time.Time) int) []int {
+		seen := map[int]bool{}
+		vs := []int{}
+
+		for _, t := range sorted {
+			if v := f(t); !seen[v] {
+				seen[v] = true
+				vs = append(vs, v)
+			}
+		}
+
+		sort.Ints(vs)
+
+		return vs
+	}
+
+	minutes := values(time.Time.Minute)
+	hours := values(time.Time.Hour)
+	days := values(time.Time.Day)
+	wdays := values(func(t time.Time) int { return int(t.Weekday()) })
+	months := values(func(t time.Time) int { return int(t.Month()) })
+	years := values(time.Time.Year)
+
+	minute := stepString(minutes, 0, 59, 59)
+	hour := stepString(hours, 0, 23, 23)
+	month := "*"
+
+	// the months and years are restricted only if there are gaps between the examples
+	if n := (last.Year()-first.Year())*12 + int(last.Month()) - int(first.Month()) + 1; n != len(values(func(t time.Time) int { return t.Year()*12 + int(t.Month()) })) {
+		month = stepString(months, 1, 12, 12)
+	}
+
+	year := "*"
+
+	if last.Year()-first.Year()+1 != len(years) {
+		year = stepString(years, minYear, maxYear, maxYear)
+	}
+
+	inferences := []*Inference{}
+	seen := map[string]bool{}
+
+	for _, days := range inferDays(sorted, days, wdays) {
+		cron, err := Parse(fmt.Sprintf("%s %s %s %s %s %s", minute, hour, days[0], month, days[1], year))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[cron.String()] {
+			continue
+		}
+
+		seen[cron.String()] = true
+		runs := []time.Time{first}
+
+		if last.After(first) {
+			runs = cron.Between(first, last)
+		}
+
+		extra := []time.Time{}
+		covered := true
+
+		for _, t := range sorted {
+			if !cron.Match(t) {
+				covered = false
+				break
+			}
+		}
+
+		if !covered {
+			continue
+		}
+
+		for _, t := range runs {
+			if !examples[t] && len(extra) < maxInferenceExtra {
+				extra = append(extra, t)
+			}
+		}
+
+		inferences = append(inferences, &Inference{
+			Expr:  cron,
+			Fit:   float64(len(sorted)) / float64(len(runs)),
+			Runs:  len(runs),
+			Extra: extra,
+		})
+	}
+
+	sort.SliceStable(inferences, func(i, j int) bool {
+		return inferences[i].Fit > inferences[j].Fit
+	})
+
+	return inferences, nil
+}
+
+// inferDays returns the pairs of day-of-month and day-of-week that cover the times,
+// from the most specific.
+func inferDays(times []time.Time, days []int, wdays []int) [][2]string {
+	all := func(f func(time.Time) bool) bool {
+		for _, t := range times {
+			if !f(t) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	candidates := [][2]string{}
+	t0 := times[0]
+	wday := t0.Weekday()
+	nth := (t0.Day()-1)/7 + 1
+	lastOffset := util.LastOfMonth(t0) - t0.Day()
+
+	if all(func(t time.Time) bool { return t.Weekday() == wday && (t.Day()-1)/7+1 == nth }) {
+		candidates = append(candidates, [2]string{"?", fmt.Sprintf("%s#%d", util.ShortWeekdayNames[wday], nth)})
+	}
+
+	if all(func(t time.Time) bool { return t.Weekday() == wday && util.LastWdayOfMonth(t, wday) == t.Day() }) {
+		candidates = append(candidates, [2]string{"?", util.ShortWeekdayNames[wday] + "L"})
+	}
+
+	if len(days) > 1 {
+		if all(func(t time.Time) bool { return util.LastOfMonth(t)-t.Day() == lastOffset }) {
+			last := LastDayOfMonth(lastOffset)
+			candidates = append(candidates, [2]string{last.String(), "?"})
+		}
+
+		if all(func(t time.Time) bool { return util.LastWeekdayOfMonth(t) == t.Day() }) {
+			candidates = append(candidates, [2]string{"LW", "?"})
+		}
+
+		// the most frequent day that the days are the nearest weekday to
+		counts := map[int]int{}
+
+		for _, t := range times {
+			counts[t.Day()]++
+		}
+
+		nearest := 0
+
+		for day := 1; day <= 31; day++ {
+			if all(func(t time.Time) bool { return util.NearestWeekday(t, day) == t.Day() }) && (nearest == 0 || counts[day] > counts[nearest]) {
+				nearest = day
+			}
+		}
+
+		if nearest > 0 {
+			candidates = append(candidates, [2]string{fmt.Sprintf("%dW", nearest), "?"})
+		}
+	}
+
+	if len(wdays) < 7 {
+		candidates = append(candidates, [2]string{"?", listString(wdays, 0, 6, 6, func(i int) string { return util.ShortWeekdayNames[i] })})
+	}
+
+	candidates = append(candidates, [2]string{stepString(days, 1, 31, 28), "?"})
+	candidates = append(candidates, [2]string{"*", "?"})
+
+	return candidates
+}
@@ -0,0 +1,157 @@
+package input
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func isCSVHeader(line string) bool {
+	header := strings.ToLower(strings.ReplaceAll(line, " ", ""))
+	return header == "name,expression" || strings.HasPrefix(header, "name,expression,")
+}
+
+// parseCSV reads records of "expr" or "name,expr". If the first record is a header that has "name" and "expression",
+// the columns are chosen by the header and the other columns are ignored (e.g. the output of "cronskd -o csv").
+func (l *loader) parseCSV(filename string, src []byte) ([]*Entry, error) {
+	r := csv.NewReader(strings.NewReader(string(src)))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	entries := []*Entry{}
+	errs := []error{}
+	nameCol, exprCol := -1, -1
+
+	for {
+		record, err := r.Read()
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			var parseErr *csv.ParseError
+
+			if errors.As(err, &parseErr) {
+				errs = append(errs, &Error{File: filename, Line: parseErr.Line, Err: parseErr.Err})
+				continue
+			}
+
+			return nil, &Error{File: filename, Err: err}
+		}
+
+		lineno, _ := r.FieldPos(0)
+
+		if exprCol < 0 && isCSVHeader(strings.Join(record, ",")) {
+			for i, col := range record {
+				switch strings.ToLower(strings.TrimSpace(col)) {
+				case "name":
+					nameCol = i
+				case "expression":
+					exprCol = i
+				}
+			}
+
+			continue
+		}
+
+		name, expr := "", ""
+
+		switch {
+		case exprCol >= 0:
+			if exprCol < len(record) {
+				expr = record[exprCol]
+			}
+
+			if nameCol < len(record) {
+				name = record[nameCol]
+			}
+		case len(record) == 1:
+			expr = record[0]
+		default:
+			name, expr = record[0], record[1]
+		}
+
+		entry, err := l.newEntry(filename, lineno, strings.TrimSpace(name), unwrap(strings.TrimSpace(expr)))
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+// parseList reads a JSON or YAML list of expressions (e.g. "0 10 * * ? *" or "name expr")
+// or of objects that have "name" and "expression".
+func (l *loader) parseList(filename string, src []byte) ([]*Entry, error) {
+	doc := &yaml.Node{}
+
+	if err := yaml.Unmarshal(src, doc); err != nil {
+		return nil, &Error{File: filename, Err: err}
+	}
+
+	if len(doc.Content) == 0 {
+		return []*Entry{}, nil
+	}
+
+	list := doc.Content[0]
+
+	if list.Kind != yaml.SequenceNode {
+		return nil, &Error{File: filename, Line: list.Line, Err: errors.New("must be a list of expressions")}
+	}
+
+	entries := []*Entry{}
+	errs := []error{}
+
+	for _, item := range list.Content {
+		var name, expr string
+		lineno := item.Line
+
+		switch item.Kind {
+		case yaml.ScalarNode:
+			name, expr, _ = SplitExpr(item.Value)
+		case yaml.MappingNode:
+			v := struct {
+				Name       string
+				Expression string
+			}{}
+
+			if err := item.Decode(&v); err != nil {
+				errs = append(errs, &Error{File: filename, Line: item.Line, Err: err})
+				continue
+			} else if v.Expression == "" {
+				errs = append(errs, &Error{File: filename, Line: item.Line, Err: errors.New("'expression' is required")})
+				continue
+			}
+
+			name = v.Name
+			expr = unwrap(strings.TrimSpace(v.Expression))
+
+			// the line of an object is the line of its expression
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				if item.Content[i].Value == "expression" {
+					lineno = item.Content[i+1].Line
+				}
+			}
+		default:
+			errs = append(errs, &Error{File: filename, Line: item.Line, Err: errors.New("must be an expression or an object that has 'expression'")})
+			continue
+		}
+
+		entry, err := l.newEntry(filename, lineno, name, expr)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, errors.Join(errs...)
+}
@@ -0,0 +1,313 @@
+// Package input loads the expressions that the CLIs read, so that all of them accept the same formats:
+// lines of "expr" or "name expr" (with comments and "@include"), CSV, and JSON or YAML lists.
+// An expression may be wrapped in "cron(...)".
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/winebarrel/cronplan/v2"
+)
+
+const (
+	// Stdin is the file name of the standard input in errors.
+	Stdin = "stdin"
+	// MaxLineSize is the maximum size of a line that a Scanner reads.
+	MaxLineSize = 1024 * 1024
+)
+
+// Entry is an expression read from the input.
+type Entry struct {
+	File string
+	Line int
+	// Name is the name of the expression. It is empty if not named.
+	Name string
+	// Expr is the expression without "cron(...)".
+	Expr string
+	// Cron is the parsed expression. It is nil if read by ParseRaw.
+	Cron *cronplan.Expression
+}
+
+// Error is an error of an input line. Line is 0 if the error is not of a line.
+type Error struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Open opens the file, or returns the standard input if name is "" or "-".
+func Open(name string) (io.ReadCloser, error) {
+	if name == "" || name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(name)
+}
+
+// ReadFile reads the file, or the standard input if name is "" or "-".
+// It returns the name to report errors with, i.e. Stdin for the standard input.
+func ReadFile(name string) (string, []byte, error) {
+	if name == "" || name == "-" {
+		src, err := io.ReadAll(os.Stdin)
+		return Stdin, src, err
+	}
+
+	src, err := os.ReadFile(name)
+	return name, src, err
+}
+
+// NewScanner returns a Scanner that reads lines up to MaxLineSize.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+	return scanner
+}
+
+// Load reads the entries from the file, or from the standard input if name is "" or "-".
+// See Parse for the formats and the errors.
+func Load(name string) ([]*Entry, error) {
+	filename, src, err := ReadFile(name)
+
+	if err != nil {
+		return nil, &Error{File: filename, Err: err}
+	}
+
+	return Parse(filename, src)
+}
+
+// Parse reads the entries from src. The format is chosen by the file extension or the content:
+// ".json", ".yml", ".yaml" or a list ("[" or "- ") is a JSON or YAML list, ".csv" or a "name,expression" header is CSV,
+// and others are lines. A line that cannot be parsed does not stop the reading:
+// the *Error values are joined and returned with the entries.
+func Parse(filename string, src []byte) ([]*Entry, error) {
+	l := &loader{included: map[string]bool{}}
+	return l.parse(filename, src)
+}
+
+// ParseRaw is like Parse, but does not parse the expressions, so that a CLI can check them by itself (e.g. cronlint).
+// The errors are only of the input (e.g. "@include" or CSV and YAML syntax) and Cron of the entries is nil.
+func ParseRaw(filename string, src []byte) ([]*Entry, error) {
+	l := &loader{included: map[string]bool{}, raw: true}
+	return l.parse(filename, src)
+}
+
+// IsAWSExport reports whether src is the JSON output of the AWS CLI (see the awsexport package)
+// rather than a list of expressions.
+func IsAWSExport(src []byte) bool {
+	trimmed := bytes.TrimSpace(src)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[' && bytes.Contains(trimmed, []byte(`"ScheduleExpression"`)))
+}
+
+// ParseArg parses an expression given as a command line argument, which may be "name expr" or wrapped in "cron(...)".
+func ParseArg(arg string) (*Entry, error) {
+	name, expr, _ := SplitExpr(arg)
+	cron, err := cronplan.Parse(expr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{Name: name, Expr: expr, Cron: cron}, nil
+}
+
+// SplitComment splits a line into the content and the comment.
+// '#' starts a comment only at the beginning of the line or after a space, so that 'MON#1' is kept.
+func SplitComment(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i], strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	return line, ""
+}
+
+// SplitExpr splits the content of a line into the name and the expression.
+// A line that has more than six fields is 'name expr'. The expression may be wrapped in 'cron(...)'.
+// It also returns the byte offset of the expression in the line.
+func SplitExpr(content string) (string, string, int) {
+	offset := len(content) - len(strings.TrimLeftFunc(content, unicode.IsSpace))
+	fields := strings.Fields(content)
+	name := ""
+
+	if len(fields) > 6 && !strings.HasPrefix(fields[0], "cron(") {
+		name = fields[0]
+		offset += len(name)
+		offset += len(content[offset:]) - len(strings.TrimLeftFunc(content[offset:], unicode.IsSpace))
+	}
+
+	expr := strings.TrimRightFunc(content[offset:], unicode.IsSpace)
+
+	if unwrapped := unwrap(expr); unwrapped != expr {
+		expr = unwrapped
+		offset += len("cron(")
+	}
+
+	return name, expr, offset
+}
+
+// unwrap removes "cron(...)" from the expression.
+func unwrap(expr string) string {
+	if strings.HasPrefix(expr, "cron(") && strings.HasSuffix(expr, ")") {
+		return strings.TrimSuffix(expr[len("cron("):], ")")
+	}
+
+	return expr
+}
+
+type loader struct {
+	// included is the set of the files being read, to detect include cycles
+	included map[string]bool
+	// raw is true not to parse the expressions
+	raw bool
+}
+
+func (l *loader) parse(filename string, src []byte) ([]*Entry, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	first := firstLine(src)
+
+	switch {
+	case ext == ".json" || ext == ".yml" || ext == ".yaml" || strings.HasPrefix(first, "[") || strings.HasPrefix(first, "-"):
+		return l.parseList(filename, src)
+	case ext == ".csv" || isCSVHeader(first):
+		return l.parseCSV(filename, src)
+	}
+
+	return l.parseLines(filename, src)
+}
+
+// firstLine returns the first line that is not blank or a comment.
+func firstLine(src []byte) string {
+	for _, line := range strings.Split(string(src), "\n") {
+		if content, _ := SplitComment(line); strings.TrimSpace(content) != "" {
+			return strings.TrimSpace(content)
+		}
+	}
+
+	return ""
+}
+
+func (l *loader) newEntry(filename string, lineno int, name string, expr string) (*Entry, error) {
+	if l.raw {
+		return &Entry{File: filename, Line: lineno, Name: name, Expr: expr}, nil
+	}
+
+	cron, err := cronplan.Parse(expr)
+
+	if err != nil {
+		return nil, &Error{File: filename, Line: lineno, Err: err}
+	}
+
+	return &Entry{File: filename, Line: lineno, Name: name, Expr: expr, Cron: cron}, nil
+}
+
+// parseLines reads lines of "expr" or "name expr". "@include PATTERN" reads the files matching the pattern,
+// relative to the directory of the file (or the current directory for the standard input).
+func (l *loader) parseLines(filename string, src []byte) ([]*Entry, error) {
+	entries := []*Entry{}
+	errs := []error{}
+
+	for i, line := range strings.Split(string(src), "\n") {
+		lineno := i + 1
+		content, _ := SplitComment(line)
+		content = strings.TrimSpace(content)
+
+		if content == "" {
+			continue
+		}
+
+		if pattern, ok := strings.CutPrefix(content, "@include"); ok && (pattern == "" || unicode.IsSpace(rune(pattern[0]))) {
+			es, err := l.include(filename, lineno, strings.TrimSpace(pattern))
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+
+			entries = append(entries, es...)
+			continue
+		}
+
+		name, expr, _ := SplitExpr(content)
+		entry, err := l.newEntry(filename, lineno, name, expr)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+// include reads the files matching the pattern. The errors of the included files are returned as they are.
+func (l *loader) include(filename string, lineno int, pattern string) ([]*Entry, error) {
+	if pattern == "" {
+		return nil, &Error{File: filename, Line: lineno, Err: errors.New("'@include' requires a file")}
+	}
+
+	if !filepath.IsAbs(pattern) && filename != Stdin {
+		pattern = filepath.Join(filepath.Dir(filename), pattern)
+	}
+
+	paths, err := filepath.Glob(pattern)
+
+	if err != nil {
+		return nil, &Error{File: filename, Line: lineno, Err: err}
+	} else if len(paths) == 0 {
+		return nil, &Error{File: filename, Line: lineno, Err: fmt.Errorf("no files match '%s'", pattern)}
+	}
+
+	if abs, err := filepath.Abs(filename); err == nil && filename != Stdin {
+		l.included[abs] = true
+		defer delete(l.included, abs)
+	}
+
+	entries := []*Entry{}
+	errs := []error{}
+
+	for _, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil && l.included[abs] {
+			errs = append(errs, &Error{File: filename, Line: lineno, Err: fmt.Errorf("include cycle: %s", path)})
+			continue
+		}
+
+		src, err := os.ReadFile(path)
+
+		if err != nil {
+			errs = append(errs, &Error{File: filename, Line: lineno, Err: err})
+			continue
+		}
+
+		es, err := l.parse(path, src)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		entries = append(entries, es...)
+	}
+
+	return entries, errors.Join(errs...)
+}
@@ -32,35 +32,57 @@ func CastMonth(s string) (time.Month, error) {
 }
 
 func LastOfMonth(t time.Time) int {
-	return t.AddDate(0, 1, -t.Day()).Day()
+	return DaysIn(t.Year(), t.Month())
 }
 
-func LastWdayOfMonth(t time.Time, w time.Weekday) int {
-	lom := t.AddDate(0, 1, -t.Day())
-
-	for i := lom; ; i = i.AddDate(0, 0, -1) {
-		if i.Weekday() == w {
-			return i.Day()
+// DaysIn returns the number of the days in the month.
+func DaysIn(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
 		}
+
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
 	}
 }
 
-func LastWeekdayOfMonth(t time.Time) int {
-	lom := t.AddDate(0, 1, -t.Day())
+// WeekdayOf returns the weekday of the day in the month of t.
+func WeekdayOf(t time.Time, day int) time.Weekday {
+	return time.Weekday(((int(t.Weekday())+day-t.Day())%7 + 7) % 7)
+}
 
-	for i := lom; ; i = i.AddDate(0, 0, -1) {
-		if i.Weekday() != time.Saturday && i.Weekday() != time.Sunday {
-			return i.Day()
-		}
+func isWeekend(w time.Weekday) bool {
+	return w == time.Saturday || w == time.Sunday
+}
+
+func LastWdayOfMonth(t time.Time, w time.Weekday) int {
+	lom := LastOfMonth(t)
+	return lom - (int(WeekdayOf(t, lom))-int(w)+7)%7
+}
+
+func LastWeekdayOfMonth(t time.Time) int {
+	lom := LastOfMonth(t)
+
+	switch WeekdayOf(t, lom) {
+	case time.Saturday:
+		return lom - 1
+	case time.Sunday:
+		return lom - 2
+	default:
+		return lom
 	}
 }
 
 func NearestWeekday(t2 time.Time, day int) int {
-	base := time.Date(t2.Year(), t2.Month(), day, 0, 0, 0, 0, t2.Location())
-	lom := LastOfMonth(time.Date(t2.Year(), t2.Month(), 1, 0, 0, 0, 0, t2.Location()))
+	lom := LastOfMonth(t2)
 
 	if day == 1 {
-		switch base.Weekday() {
+		switch WeekdayOf(t2, day) {
 		case time.Saturday:
 			return 3
 		case time.Sunday:
@@ -72,31 +94,35 @@ func NearestWeekday(t2 time.Time, day int) int {
 		return 0
 	} else if day == lom {
 		for i := 0; i <= 2; i++ {
-			prev := base.AddDate(0, 0, -i)
-
-			if prev.Month() == t2.Month() && prev.Weekday() != time.Saturday && prev.Weekday() != time.Sunday {
-				return prev.Day()
+			if !isWeekend(WeekdayOf(t2, day-i)) {
+				return day - i
 			}
 		}
 
 		return 0
-	} else if base.Weekday() == time.Saturday {
-		return base.AddDate(0, 0, -1).Day()
-	} else if base.Weekday() == time.Sunday {
-		return base.AddDate(0, 0, 1).Day()
-	} else {
+	}
+
+	switch WeekdayOf(t2, day) {
+	case time.Saturday:
+		return day - 1
+	case time.Sunday:
+		return day + 1
+	default:
 		return day
 	}
 }
 
 func NthDayOfWeek(t time.Time, wday time.Weekday, nth int) int {
-	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
-	offset := (wday + 7 - firstOfMonth.Weekday()) % 7
-	nthDoW := firstOfMonth.AddDate(0, 0, 7*(nth-1)+int(offset))
+	if nth < 1 || 5 < nth {
+		return 0
+	}
+
+	offset := (int(wday) - int(WeekdayOf(t, 1)) + 7) % 7
+	day := 1 + 7*(nth-1) + offset
 
-	if nthDoW.Month() != t.Month() {
+	if day > LastOfMonth(t) {
 		return 0
 	}
 
-	return nthDoW.Day()
+	return day
 }
@@ -46,11 +46,20 @@ func ListHour(start int, end int) ([]int, error) {
 func ListDayOfMonth(t time.Time, start int, end int) ([]int, error) {
 	lom := LastOfMonth(t)
 
+	// the days after the end of the month are skipped
+	if start > lom {
+		if start <= end {
+			return []int{}, nil
+		}
+
+		start = 1
+	}
+
 	if end > lom {
 		end = lom
 	}
 
-	return List(start, end, 1, LastOfMonth(t))
+	return List(start, end, 1, lom)
 }
 
 func ListMonth(start time.Month, end time.Month) ([]time.Month, error) {
@@ -88,3 +97,13 @@ func ListWeekday(start time.Weekday, end time.Weekday) ([]time.Weekday, error) {
 func ListYear(start int, end int) ([]int, error) {
 	return List(start, end, 1970, 2199)
 }
+
+// InRange reports whether n is in start-end without allocation.
+// The range wraps around if start > end, like List.
+func InRange(n int, start int, end int) bool {
+	if start <= end {
+		return start <= n && n <= end
+	}
+
+	return start <= n || n <= end
+}
@@ -0,0 +1,83 @@
+package zone
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	// Embed the time zone database so that '-tz' works without the system database.
+	_ "time/tzdata"
+)
+
+var offsetRegexp = regexp.MustCompile(`^([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// Load returns the location to parse and display dates, and the location to evaluate expressions.
+// If tz or utcOffset is specified, expressions are evaluated in UTC as EventBridge does.
+// Otherwise, dates are parsed in the local time zone and the location to evaluate is nil,
+// so that a date with an explicit offset is evaluated in its own zone.
+func Load(tz string, utcOffset string) (*time.Location, *time.Location, error) {
+	if tz != "" && utcOffset != "" {
+		return nil, nil, errors.New("'-tz' and '-utc-offset' cannot be used together")
+	} else if tz != "" {
+		loc, err := time.LoadLocation(tz)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return loc, time.UTC, nil
+	} else if utcOffset != "" {
+		loc, err := ParseOffset(utcOffset)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return loc, time.UTC, nil
+	}
+
+	return time.Local, nil, nil
+}
+
+// In returns t in evalLoc, or t as it is if evalLoc is nil.
+func In(t time.Time, evalLoc *time.Location) time.Time {
+	if evalLoc == nil {
+		return t
+	}
+
+	return t.In(evalLoc)
+}
+
+// ParseOffset returns a fixed location from a UTC offset such as "+09:00", "-0330" or "+9".
+func ParseOffset(s string) (*time.Location, error) {
+	if s == "Z" || s == "UTC" {
+		return time.UTC, nil
+	}
+
+	m := offsetRegexp.FindStringSubmatch(s)
+
+	if m == nil {
+		return nil, fmt.Errorf("cannot convert to UTC offset from %s", s)
+	}
+
+	hour, _ := strconv.Atoi(m[2])
+	minute := 0
+
+	if m[3] != "" {
+		minute, _ = strconv.Atoi(m[3])
+	}
+
+	if hour > 14 || minute > 59 {
+		return nil, fmt.Errorf("UTC offset must be -14:00 to +14:00 (value=%s)", s)
+	}
+
+	offset := hour*60*60 + minute*60
+
+	if m[1] == "-" {
+		offset = -offset
+	}
+
+	return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", m[1], hour, minute), offset), nil
+}
@@ -0,0 +1,99 @@
+package cronplan
+
+import (
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+// Interval is a gap between two consecutive occurrences.
+type Interval struct {
+	Duration time.Duration
+	// From and To are the earliest pair of the occurrences that produce the gap.
+	From time.Time
+	To   time.Time
+}
+
+// MinInterval returns the shortest gap between two consecutive occurrences over the whole period (1970-2199).
+// The occurrences are evaluated in UTC. It returns nil if there are fewer than two occurrences.
+func (v *Expression) MinInterval() *Interval {
+	min, _ := v.intervals()
+	return min
+}
+
+// MaxInterval returns the longest gap between two consecutive occurrences over the whole period (1970-2199).
+// The occurrences are evaluated in UTC. It returns nil if there are fewer than two occurrences.
+func (v *Expression) MaxInterval() *Interval {
+	_, max := v.intervals()
+	return max
+}
+
+// intervals finds the extreme gaps from the times of a day and the gaps between the matching days,
+// since every matching day has the same times.
+func (v *Expression) intervals() (*Interval, *Interval) {
+	c := v.newCounter()
+
+	if c == nil {
+		return nil, nil
+	}
+
+	times := make([]int, 0, c.perDay())
+
+	for _, hour := range c.hours {
+		for _, minute := range c.minutes {
+			times = append(times, hour*60+minute)
+		}
+	}
+
+	first := times[0]
+	last := times[len(times)-1]
+	var min, max *Interval
+
+	// the pairs come in order, so the first pair of each extreme is kept
+	update := func(from time.Time, to time.Time) {
+		d := to.Sub(from)
+
+		if min == nil || d < min.Duration {
+			min = &Interval{Duration: d, From: from, To: to}
+		}
+
+		if max == nil || d > max.Duration {
+			max = &Interval{Duration: d, From: from, To: to}
+		}
+	}
+
+	var prev time.Time
+
+	for year := minYear; year <= maxYear; year++ {
+		if c.year(year) == 0 {
+			continue
+		}
+
+		for month := time.January; month <= time.December; month++ {
+			if c.month(year, month) == 0 {
+				continue
+			}
+
+			for day := 1; day <= util.DaysIn(year, month); day++ {
+				t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+				if !c.dayMatch(t) {
+					continue
+				}
+
+				if prev.IsZero() {
+					// the gaps in a day are the same on every matching day
+					for i := 1; i < len(times); i++ {
+						update(t.Add(time.Duration(times[i-1])*time.Minute), t.Add(time.Duration(times[i])*time.Minute))
+					}
+				} else {
+					update(prev.Add(time.Duration(last)*time.Minute), t.Add(time.Duration(first)*time.Minute))
+				}
+
+				prev = t
+			}
+		}
+	}
+
+	return min, max
+}
@@ -0,0 +1,220 @@
+// Package kube reads Kubernetes CronJobs from manifests, so that their schedules can be evaluated offline
+// together with EventBridge expressions.
+package kube
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/crontab"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ConcurrencyPolicyAllow   = "Allow"
+	ConcurrencyPolicyForbid  = "Forbid"
+	ConcurrencyPolicyReplace = "Replace"
+)
+
+// CronJob is a Kubernetes CronJob.
+type CronJob struct {
+	// Line is the line of spec.schedule in the manifest.
+	Line      int
+	Namespace string
+	Name      string
+	// Schedule is spec.schedule, e.g. "0 9 * * 1-5", "@daily" or "CRON_TZ=Asia/Tokyo 0 9 * * *".
+	Schedule string
+	// TimeZone is spec.timeZone. It is empty if not set.
+	TimeZone string
+	Suspend  bool
+	// ConcurrencyPolicy is spec.concurrencyPolicy. It is ConcurrencyPolicyAllow if not set.
+	ConcurrencyPolicy string
+}
+
+type object struct {
+	Kind  string
+	Items []yaml.Node
+}
+
+type cronJob struct {
+	Metadata struct {
+		Name      string
+		Namespace string
+	}
+	Spec struct {
+		Schedule          yaml.Node
+		TimeZone          string `yaml:"timeZone"`
+		Suspend           bool
+		ConcurrencyPolicy string `yaml:"concurrencyPolicy"`
+	}
+}
+
+// IsManifest reports whether the first document of src is a Kubernetes object (i.e. has apiVersion and kind).
+func IsManifest(src []byte) bool {
+	var doc map[string]any
+
+	if err := yaml.NewDecoder(bytes.NewReader(src)).Decode(&doc); err != nil {
+		return false
+	}
+
+	_, hasAPIVersion := doc["apiVersion"]
+	_, hasKind := doc["kind"]
+	return hasAPIVersion && hasKind
+}
+
+// Read reads the CronJobs from YAML or JSON manifests.
+// The documents may be separated by "---", and the items of a list (e.g. the output of "kubectl get cronjobs -o yaml")
+// are read. Objects of other kinds are skipped.
+func Read(r io.Reader) ([]*CronJob, error) {
+	dec := yaml.NewDecoder(r)
+	jobs := []*CronJob{}
+
+	for {
+		doc := &yaml.Node{}
+
+		if err := dec.Decode(doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+
+		js, err := readObject(doc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, js...)
+	}
+
+	return jobs, nil
+}
+
+func readObject(node *yaml.Node) ([]*CronJob, error) {
+	obj := &object{}
+
+	if err := node.Decode(obj); err != nil {
+		// not an object
+		return nil, nil
+	}
+
+	if obj.Kind != "CronJob" {
+		jobs := []*CronJob{}
+
+		for i := range obj.Items {
+			js, err := readObject(&obj.Items[i])
+
+			if err != nil {
+				return nil, err
+			}
+
+			jobs = append(jobs, js...)
+		}
+
+		return jobs, nil
+	}
+
+	cj := &cronJob{}
+
+	if err := node.Decode(cj); err != nil {
+		return nil, fmt.Errorf("failed to parse CronJob '%s': %w", cj.Metadata.Name, err)
+	}
+
+	job := &CronJob{
+		Line:              cj.Spec.Schedule.Line,
+		Namespace:         cj.Metadata.Namespace,
+		Name:              cj.Metadata.Name,
+		Schedule:          strings.TrimSpace(cj.Spec.Schedule.Value),
+		TimeZone:          cj.Spec.TimeZone,
+		Suspend:           cj.Spec.Suspend,
+		ConcurrencyPolicy: cj.Spec.ConcurrencyPolicy,
+	}
+
+	if cj.Spec.Schedule.Kind != yaml.ScalarNode || job.Schedule == "" {
+		return nil, fmt.Errorf("CronJob '%s' has no spec.schedule", job.FullName())
+	}
+
+	if job.ConcurrencyPolicy == "" {
+		job.ConcurrencyPolicy = ConcurrencyPolicyAllow
+	}
+
+	return []*CronJob{job}, nil
+}
+
+// FullName returns "namespace/name", or the name if the namespace is not set.
+func (j *CronJob) FullName() string {
+	if j.Namespace == "" {
+		return j.Name
+	}
+
+	return j.Namespace + "/" + j.Name
+}
+
+func (j *CronJob) parse() ([]*cronplan.Expression, *time.Location, error) {
+	exprs, tz, err := crontab.ConvertKubernetes(j.Schedule)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tz != "" && j.TimeZone != "" {
+		return nil, nil, fmt.Errorf("cannot use both spec.timeZone and TZ or CRON_TZ in spec.schedule")
+	} else if tz == "" {
+		tz = j.TimeZone
+	}
+
+	loc := time.UTC
+
+	if tz != "" {
+		loc, err = time.LoadLocation(tz)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return exprs, loc, nil
+}
+
+// Location returns the time zone to evaluate the schedule in: spec.timeZone, the "CRON_TZ=" or "TZ=" prefix
+// of spec.schedule, or UTC. Without them, the controller uses its local time zone, which is usually UTC.
+func (j *CronJob) Location() (*time.Location, error) {
+	_, loc, err := j.parse()
+	return loc, err
+}
+
+// Expressions returns the EventBridge expressions that fire at the same times as the schedule.
+// See crontab.ConvertKubernetes for the dialect.
+func (j *CronJob) Expressions() ([]*cronplan.Expression, error) {
+	exprs, _, err := j.parse()
+	return exprs, err
+}
+
+// Between returns the occurrences from from to to, evaluated in the time zone of the CronJob.
+// The occurrences are sorted and returned in the location of from. Suspend is not taken into account.
+func (j *CronJob) Between(from time.Time, to time.Time) ([]time.Time, error) {
+	exprs, loc, err := j.parse()
+
+	if err != nil {
+		return nil, err
+	}
+
+	times := []time.Time{}
+
+	for _, e := range exprs {
+		for _, t := range e.Between(from.In(loc), to.In(loc)) {
+			times = append(times, t.In(from.Location()))
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	// a day that matches both day-of-month and day-of-week is returned by both expressions
+	return slices.CompactFunc(times, time.Time.Equal), nil
+}
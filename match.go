@@ -13,20 +13,7 @@ func (v *Minute) Match(t time.Time) bool {
 }
 
 func (v *MinuteRange) Match(t time.Time) bool {
-	minute := t.Minute()
-	list, err := util.ListMinute(v.Start.Int(), v.End.Int())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == minute {
-			return true
-		}
-	}
-
-	return false
+	return util.InRange(t.Minute(), v.Start.Int(), v.End.Int())
 }
 
 func (e *MinuteExp) Match(t time.Time) bool {
@@ -46,19 +33,7 @@ func (e *MinuteExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListMinute(start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == minute && i%bottom == start%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= minute && minute <= end && minute%bottom == start%bottom
 		} else {
 			var top int
 
@@ -108,20 +83,7 @@ func (v *Hour) Match(t time.Time) bool {
 }
 
 func (v *HourRange) Match(t time.Time) bool {
-	hour := t.Hour()
-	list, err := util.ListHour(v.Start.Int(), v.End.Int())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == hour {
-			return true
-		}
-	}
-
-	return false
+	return util.InRange(t.Hour(), v.Start.Int(), v.End.Int())
 }
 
 func (e *HourExp) Match(t time.Time) bool {
@@ -141,19 +103,7 @@ func (e *HourExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListMinute(start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == hour && i%bottom == start%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= hour && hour <= end && hour%bottom == start%bottom
 		} else {
 			var top int
 
@@ -203,20 +153,8 @@ func (v *DayOfMonth) Match(t time.Time) bool {
 }
 
 func (v *DayOfMonthRange) Match(t time.Time) bool {
-	day := t.Day()
-	list, err := util.ListDayOfMonth(t, v.Start.Int(), v.End.Int())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == day {
-			return true
-		}
-	}
-
-	return false
+	// unlike util.ListDayOfMonth, no clamping is needed because t.Day() never exceeds the end of the month
+	return util.InRange(t.Day(), v.Start.Int(), v.End.Int())
 }
 
 func (v *NearestWeekday) Match(t time.Time) bool {
@@ -254,19 +192,7 @@ func (e *DayOfMonthExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListDayOfMonth(t, start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == day && i%bottom == start%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= day && day <= end && day%bottom == start%bottom
 		} else {
 			var top int
 
@@ -320,20 +246,7 @@ func (v *Month) Match(t time.Time) bool {
 }
 
 func (v *MonthRange) Match(t time.Time) bool {
-	month := t.Month()
-	list, err := util.ListMonth(v.Start.Month(), v.End.Month())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == month {
-			return true
-		}
-	}
-
-	return false
+	return util.InRange(int(t.Month()), v.Start.Int(), v.End.Int())
 }
 
 func (e *MonthExp) Match(t time.Time) bool {
@@ -353,19 +266,7 @@ func (e *MonthExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListMonth(start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == month && int(i)%bottom == int(start)%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= month && month <= end && int(month)%bottom == int(start)%bottom
 		} else {
 			var top time.Month
 
@@ -415,20 +316,7 @@ func (v *Weekday) Match(t time.Time) bool {
 }
 
 func (v *WeekdayRange) Match(t time.Time) bool {
-	wday := t.Weekday()
-	list, err := util.ListWeekday(v.Start.Weekday(), v.End.Weekday())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == wday {
-			return true
-		}
-	}
-
-	return false
+	return util.InRange(int(t.Weekday()), v.Start.Int(), v.End.Int())
 }
 
 func (v *NthDayOfWeek) Match(t time.Time) bool {
@@ -466,19 +354,7 @@ func (e *DayOfWeekExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListWeekday(start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == wday && int(i)%bottom == int(start)%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= wday && wday <= end && int(wday)%bottom == int(start)%bottom
 		} else {
 			var top time.Weekday
 
@@ -532,20 +408,7 @@ func (v *Year) Match(t time.Time) bool {
 }
 
 func (v *YearRange) Match(t time.Time) bool {
-	year := t.Year()
-	list, err := util.ListYear(v.Start.Int(), v.End.Int())
-
-	if err != nil {
-		panic(err)
-	}
-
-	for _, i := range list {
-		if i == year {
-			return true
-		}
-	}
-
-	return false
+	return util.InRange(t.Year(), v.Start.Int(), v.End.Int())
 }
 
 func (e *YearExp) Match(t time.Time) bool {
@@ -565,19 +428,7 @@ func (e *YearExp) Match(t time.Time) bool {
 				return false
 			}
 
-			list, err := util.ListYear(start, end)
-
-			if err != nil {
-				panic(err)
-			}
-
-			for _, i := range list {
-				if i == year && i%bottom == start%bottom {
-					return true
-				}
-			}
-
-			return false
+			return start <= year && year <= end && year%bottom == start%bottom
 		} else {
 			var top int
 
@@ -630,3 +481,15 @@ func (v *Expression) Match(t time.Time) bool {
 		v.DayOfWeek.Match(t) &&
 		v.Year.Match(t)
 }
+
+// MatchWithin reports whether t is within d after a scheduled time,
+// and returns the scheduled time.
+func (v *Expression) MatchWithin(t time.Time, d time.Duration) (time.Time, bool) {
+	prev := v.Prev(t)
+
+	if prev.IsZero() || t.Sub(prev) > d {
+		return time.Time{}, false
+	}
+
+	return prev, true
+}
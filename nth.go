@@ -0,0 +1,217 @@
+package cronplan
+
+import (
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+// counter counts the occurrences of an expression by the field cardinalities and month calendars
+// instead of enumerating them. The occurrences are counted from the beginning of minYear.
+type counter struct {
+	expr     *Expression
+	hours    []int
+	minutes  []int
+	dayMatch func(time.Time) bool
+	// years caches the count of a year by the leap year and the weekday of January 1
+	years [2][7]int
+}
+
+func (v *Expression) newCounter() *counter {
+	c := &counter{
+		expr:    v,
+		hours:   v.candidateHours(time.Time{}),
+		minutes: v.candidateMinutes(time.Time{}),
+	}
+
+	if !v.DayOfMonth.Any && v.DayOfWeek.Any {
+		c.dayMatch = v.DayOfMonth.Match
+	} else if v.DayOfMonth.Any && !v.DayOfWeek.Any {
+		c.dayMatch = v.DayOfWeek.Match
+	} else {
+		return nil
+	}
+
+	if len(c.hours) == 0 || len(c.minutes) == 0 {
+		return nil
+	}
+
+	for i := range c.years {
+		for j := range c.years[i] {
+			c.years[i][j] = -1
+		}
+	}
+
+	return c
+}
+
+func (c *counter) perDay() int {
+	return len(c.hours) * len(c.minutes)
+}
+
+func (c *counter) year(year int) int {
+	if !c.expr.Year.Match(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		return 0
+	}
+
+	leap := 0
+
+	if util.DaysIn(year, time.February) == 29 {
+		leap = 1
+	}
+
+	wday := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Weekday()
+
+	if c.years[leap][wday] < 0 {
+		n := 0
+
+		for month := time.January; month <= time.December; month++ {
+			n += c.month(year, month)
+		}
+
+		c.years[leap][wday] = n
+	}
+
+	return c.years[leap][wday]
+}
+
+func (c *counter) month(year int, month time.Month) int {
+	return c.days(year, month, util.DaysIn(year, month)+1) * c.perDay()
+}
+
+// days returns the number of the matching days before day in the month.
+func (c *counter) days(year int, month time.Month, day int) int {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	if !c.expr.Year.Match(t) || !c.expr.Month.Match(t) {
+		return 0
+	}
+
+	n := 0
+
+	for d := 1; d < day; d++ {
+		if c.dayMatch(time.Date(year, month, d, 0, 0, 0, 0, time.UTC)) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// times returns the number of the times of a day before hour:minute.
+func (c *counter) times(hour int, minute int) int {
+	n := 0
+
+	for _, h := range c.hours {
+		if h < hour {
+			n += len(c.minutes)
+		} else if h == hour {
+			for _, m := range c.minutes {
+				if m < minute {
+					n++
+				}
+			}
+		}
+	}
+
+	return n
+}
+
+// until returns the number of the occurrences before the minute of t.
+func (c *counter) until(t time.Time) int {
+	n := 0
+
+	for year := minYear; year < t.Year() && year <= maxYear; year++ {
+		n += c.year(year)
+	}
+
+	if t.Year() < minYear || maxYear < t.Year() {
+		return n
+	}
+
+	for month := time.January; month < t.Month(); month++ {
+		n += c.month(t.Year(), month)
+	}
+
+	n += c.days(t.Year(), t.Month(), t.Day()) * c.perDay()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if c.expr.Year.Match(day) && c.expr.Month.Match(day) && c.dayMatch(day) {
+		n += c.times(t.Hour(), t.Minute())
+	}
+
+	return n
+}
+
+// nth returns the i-th (0-based) occurrence.
+func (c *counter) nth(i int, loc *time.Location) time.Time {
+	if i < 0 {
+		// overflowed
+		return time.Time{}
+	}
+
+	for year := minYear; year <= maxYear; year++ {
+		if n := c.year(year); i >= n {
+			i -= n
+			continue
+		}
+
+		for month := time.January; month <= time.December; month++ {
+			if n := c.month(year, month); i >= n {
+				i -= n
+				continue
+			}
+
+			for day := 1; ; day++ {
+				if !c.dayMatch(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)) {
+					continue
+				}
+
+				if i >= c.perDay() {
+					i -= c.perDay()
+					continue
+				}
+
+				hour := c.hours[i/len(c.minutes)]
+				minute := c.minutes[i%len(c.minutes)]
+
+				return time.Date(year, month, day, hour, minute, 0, 0, loc)
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// NthAfter returns the k-th occurrence at or after from, so NthAfter(from, 1) equals Next(from).
+// It counts whole years, months and days without enumerating the occurrences.
+// It returns the zero time if there are not k occurrences.
+func (v *Expression) NthAfter(from time.Time, k int) time.Time {
+	c := v.newCounter()
+
+	if c == nil || k < 1 {
+		return time.Time{}
+	}
+
+	return c.nth(c.until(from)+k-1, from.Location())
+}
+
+// IndexOf returns the rank of the occurrence t since from, so NthAfter(from, IndexOf(from, t)) equals t.
+// t is evaluated in the time zone of from.
+// It returns false if t is not an occurrence at or after from.
+func (v *Expression) IndexOf(from time.Time, t time.Time) (int, bool) {
+	c := v.newCounter()
+	t = t.In(from.Location())
+
+	if c == nil || t.Second() != 0 || t.Nanosecond() != 0 || !v.Match(t) || t.Year() < minYear || maxYear < t.Year() {
+		return 0, false
+	}
+
+	i := c.until(t) - c.until(from)
+
+	if i < 0 {
+		return 0, false
+	}
+
+	return i + 1, true
+}
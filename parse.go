@@ -22,9 +22,13 @@ var (
 		{Name: `SP`, Pattern: `\s+`},
 	})
 
+	// Parser is the participle parser of expressions.
+	// Parse uses a hand-written parser that accepts the same expressions.
 	Parser = participle.MustBuild[Expression](
 		participle.Lexer(cronLexer),
 	)
+
+	numberRegexp = regexp.MustCompile(`^\d+$`)
 )
 
 // minute =====================================================================
@@ -33,9 +37,7 @@ type Minute int
 
 func (v *Minute) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to minute from %s", s)
 	}
 
@@ -112,9 +114,7 @@ type Hour int
 
 func (v *Hour) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to hour from %s", s)
 	}
 
@@ -191,9 +191,7 @@ type DayOfMonth int
 
 func (v *DayOfMonth) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to day-of-month from %s", s)
 	}
 
@@ -229,9 +227,7 @@ type NearestWeekday int
 
 func (v *NearestWeekday) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to nearest_weekday from %sW", s)
 	}
 
@@ -264,9 +260,7 @@ func (v *LastDayOfMonth) Capture(values []string) error {
 		return nil
 	}
 
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to last_day-of-month from L-%s", s)
 	}
 
@@ -363,9 +357,7 @@ type Month int
 
 func (v *Month) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if r.MatchString(s) {
+	if numberRegexp.MatchString(s) {
 		n, _ := strconv.Atoi(s)
 
 		if n < 1 || 12 < n {
@@ -452,9 +444,7 @@ type Weekday time.Weekday
 
 func (v *Weekday) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if r.MatchString(s) {
+	if numberRegexp.MatchString(s) {
 		n, _ := strconv.Atoi(s)
 
 		if n < 1 || 7 < n {
@@ -577,9 +567,7 @@ type Year int
 
 func (v *Year) Capture(values []string) error {
 	s := values[0]
-	r := regexp.MustCompile(`^\d+$`)
-
-	if !r.MatchString(s) {
+	if !numberRegexp.MatchString(s) {
 		return fmt.Errorf("connot convert to year from %s", s)
 	}
 
@@ -662,8 +650,8 @@ type Expression struct {
 }
 
 func Parse(exp string) (*Expression, error) {
-	exp = strings.TrimSpace(exp)
-	cron, err := Parser.ParseString("", exp)
+	p := &parser{}
+	cron, err := p.parse(exp)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,605 @@
+package cronplan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+// SyntaxError is an error in an expression with its position.
+type SyntaxError struct {
+	// Line and Column are 1-based. Column counts runes.
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenMonth
+	tokenWeekday
+	tokenSymbol
+	tokenSpace
+	tokenInvalid
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) is(symbol byte) bool {
+	return t.kind == tokenSymbol && t.text[0] == symbol
+}
+
+func (t token) isValue(name tokenKind) bool {
+	return t.kind == tokenNumber || t.kind == name
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenSpace:
+		return "space"
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// parser is a recursive-descent parser that accepts the same expressions as Parser.
+type parser struct {
+	input  string
+	offset int
+	src    string
+	pos    int
+	values [1]string
+}
+
+func (p *parser) peek() token {
+	s := p.src[p.pos:]
+
+	if s == "" {
+		return token{kind: tokenEOF, pos: p.pos}
+	}
+
+	if n := prefixLen(s, isDigit); n > 0 {
+		return token{kind: tokenNumber, text: s[:n], pos: p.pos}
+	}
+
+	if len(s) >= 3 {
+		for _, name := range util.ShortMonthNames {
+			if strings.EqualFold(s[:3], name) {
+				return token{kind: tokenMonth, text: s[:3], pos: p.pos}
+			}
+		}
+
+		for _, name := range util.ShortWeekdayNames {
+			if strings.EqualFold(s[:3], name) {
+				return token{kind: tokenWeekday, text: s[:3], pos: p.pos}
+			}
+		}
+	}
+
+	if strings.IndexByte(",-*?/LW#", s[0]) >= 0 {
+		return token{kind: tokenSymbol, text: s[:1], pos: p.pos}
+	}
+
+	if n := prefixLen(s, isSpace); n > 0 {
+		return token{kind: tokenSpace, text: s[:n], pos: p.pos}
+	}
+
+	_, n := utf8.DecodeRuneInString(s)
+
+	return token{kind: tokenInvalid, text: s[:n], pos: p.pos}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos += len(t.text)
+	return t
+}
+
+func (p *parser) accept(symbol byte) bool {
+	if p.peek().is(symbol) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) errorf(pos int, format string, a ...any) error {
+	pos += p.offset
+	line := strings.Count(p.input[:pos], "\n")
+	col := utf8.RuneCountInString(p.input[strings.LastIndexByte(p.input[:pos], '\n')+1 : pos])
+
+	return &SyntaxError{
+		Line:   line + 1,
+		Column: col + 1,
+		Msg:    fmt.Sprintf(format, a...),
+	}
+}
+
+func (p *parser) unexpected(t token, field string) error {
+	return p.errorf(t.pos, "unexpected %s in %s", t, field)
+}
+
+// int reads the number after '/' and '#'.
+// Like Parser, it is read with a base prefix, so "010" is 8.
+func (p *parser) int(field string) (*int, error) {
+	t := p.next()
+
+	if t.kind != tokenNumber {
+		return nil, p.unexpected(t, field)
+	}
+
+	n, err := strconv.ParseInt(t.text, 0, 64)
+
+	if err != nil {
+		return nil, p.errorf(t.pos, "invalid number %s in %s", t, field)
+	}
+
+	i := int(n)
+
+	return &i, nil
+}
+
+func (p *parser) bottom(field string) (*int, error) {
+	if !p.accept('/') {
+		return nil, nil
+	}
+
+	return p.int(field)
+}
+
+type capturer[T any] interface {
+	*T
+	Capture(values []string) error
+}
+
+func capture[T any, PT capturer[T]](p *parser, t token) (*T, error) {
+	v := PT(new(T))
+	p.values[0] = t.text
+
+	if err := v.Capture(p.values[:]); err != nil {
+		return nil, p.errorf(t.pos, "%s", err)
+	}
+
+	return (*T)(v), nil
+}
+
+// captureRange captures the start t and the end after '-' if any.
+func captureRange[T any, PT capturer[T]](p *parser, t token, name tokenKind, field string) (*T, *T, error) {
+	start, err := capture[T, PT](p, t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !p.accept('-') {
+		return start, nil, nil
+	}
+
+	t = p.next()
+
+	if !t.isValue(name) {
+		return nil, nil, p.unexpected(t, field)
+	}
+
+	end, err := capture[T, PT](p, t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return start, end, nil
+}
+
+func (p *parser) parse(exp string) (*Expression, error) {
+	p.input = exp
+	p.src = strings.TrimSpace(exp)
+	p.offset = len(exp) - len(strings.TrimLeftFunc(exp, unicode.IsSpace))
+	cron := &Expression{}
+	var err error
+
+	if cron.Minute, err = p.minuteField(); err != nil {
+		return nil, err
+	}
+
+	if err = p.space("hour"); err != nil {
+		return nil, err
+	}
+
+	if cron.Hour, err = p.hourField(); err != nil {
+		return nil, err
+	}
+
+	if err = p.space("day-of-month"); err != nil {
+		return nil, err
+	}
+
+	if cron.DayOfMonth, err = p.dayOfMonthField(); err != nil {
+		return nil, err
+	}
+
+	if err = p.space("month"); err != nil {
+		return nil, err
+	}
+
+	if cron.Month, err = p.monthField(); err != nil {
+		return nil, err
+	}
+
+	if err = p.space("day-of-week"); err != nil {
+		return nil, err
+	}
+
+	if cron.DayOfWeek, err = p.dayOfWeekField(); err != nil {
+		return nil, err
+	}
+
+	if err = p.space("year"); err != nil {
+		return nil, err
+	}
+
+	if cron.Year, err = p.yearField(); err != nil {
+		return nil, err
+	}
+
+	if t := p.next(); t.kind != tokenEOF {
+		if t.kind == tokenSpace {
+			t = p.next()
+		}
+
+		return nil, p.errorf(t.pos, "unexpected %s after year", t)
+	}
+
+	return cron, nil
+}
+
+// space reads the separator before the next field.
+func (p *parser) space(field string) error {
+	t := p.next()
+
+	switch t.kind {
+	case tokenSpace:
+		return nil
+	case tokenEOF:
+		return p.errorf(t.pos, "missing %s", field)
+	default:
+		return p.errorf(t.pos, "unexpected %s before %s", t, field)
+	}
+}
+
+func (p *parser) minuteField() (*MinuteField, error) {
+	v := &MinuteField{}
+
+	for {
+		e := &MinuteExp{}
+		t := p.next()
+
+		switch {
+		case t.is('*'):
+			e.Wildcard = true
+		case t.kind == tokenNumber:
+			start, end, err := captureRange[Minute](p, t, tokenNumber, "minute")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if end != nil {
+				e.Range = &MinuteRange{Start: start, End: end}
+			} else {
+				e.Number = start
+			}
+		default:
+			return nil, p.unexpected(t, "minute")
+		}
+
+		var err error
+
+		if e.Bottom, err = p.bottom("minute"); err != nil {
+			return nil, err
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) hourField() (*HourField, error) {
+	v := &HourField{}
+
+	for {
+		e := &HourExp{}
+		t := p.next()
+
+		switch {
+		case t.is('*'):
+			e.Wildcard = true
+		case t.kind == tokenNumber:
+			start, end, err := captureRange[Hour](p, t, tokenNumber, "hour")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if end != nil {
+				e.Range = &HourRange{Start: start, End: end}
+			} else {
+				e.Number = start
+			}
+		default:
+			return nil, p.unexpected(t, "hour")
+		}
+
+		var err error
+
+		if e.Bottom, err = p.bottom("hour"); err != nil {
+			return nil, err
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) dayOfMonthField() (*DayOfMonthField, error) {
+	if p.accept('?') {
+		return &DayOfMonthField{Any: true}, nil
+	}
+
+	v := &DayOfMonthField{}
+
+	for {
+		e := &DayOfMonthExp{}
+		t := p.next()
+		var err error
+
+		switch {
+		case t.kind == tokenNumber && p.peek().is('W'):
+			p.pos++
+
+			if e.NearestWeekday, err = capture[NearestWeekday](p, t); err != nil {
+				return nil, err
+			}
+		case t.is('L') && p.peek().is('W'):
+			p.pos++
+			e.LastWeekday = &LastWeekdayOfMonth{}
+		case t.is('L'):
+			if p.accept('-') {
+				t = p.next()
+
+				if t.kind != tokenNumber {
+					return nil, p.unexpected(t, "day-of-month")
+				}
+			}
+
+			if e.Last, err = capture[LastDayOfMonth](p, t); err != nil {
+				return nil, err
+			}
+		case t.is('*'), t.kind == tokenNumber:
+			if t.is('*') {
+				e.Wildcard = true
+			} else {
+				start, end, err := captureRange[DayOfMonth](p, t, tokenNumber, "day-of-month")
+
+				if err != nil {
+					return nil, err
+				}
+
+				if end != nil {
+					e.Range = &DayOfMonthRange{Start: start, End: end}
+				} else {
+					e.Number = start
+				}
+			}
+
+			if e.Bottom, err = p.bottom("day-of-month"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.unexpected(t, "day-of-month")
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) monthField() (*MonthField, error) {
+	v := &MonthField{}
+
+	for {
+		e := &MonthExp{}
+		t := p.next()
+
+		switch {
+		case t.is('*'):
+			e.Wildcard = true
+		case t.isValue(tokenMonth):
+			start, end, err := captureRange[Month](p, t, tokenMonth, "month")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if end != nil {
+				e.Range = &MonthRange{Start: start, End: end}
+			} else {
+				e.Month = start
+			}
+		default:
+			return nil, p.unexpected(t, "month")
+		}
+
+		var err error
+
+		if e.Bottom, err = p.bottom("month"); err != nil {
+			return nil, err
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) dayOfWeekField() (*DayOfWeekField, error) {
+	if p.accept('?') {
+		return &DayOfWeekField{Any: true}, nil
+	}
+
+	v := &DayOfWeekField{}
+
+	for {
+		e := &DayOfWeekExp{}
+		t := p.next()
+		var err error
+
+		switch {
+		case t.is('L'):
+			e.Last = &LastDayOfWeek{}
+		case t.is('*'):
+			e.Wildcard = true
+
+			if e.Bottom, err = p.bottom("day-of-week"); err != nil {
+				return nil, err
+			}
+		case t.isValue(tokenWeekday):
+			next := p.peek()
+
+			if next.is('#') || next.is('L') {
+				p.pos++
+				wday, err := capture[Weekday](p, t)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if next.is('L') {
+					e.Last = &LastDayOfWeek{Wday: wday}
+					break
+				}
+
+				e.Nth = &NthDayOfWeek{Wday: wday}
+				nth, err := p.int("day-of-week")
+
+				if err != nil {
+					return nil, err
+				}
+
+				e.Nth.Nth = *nth
+				break
+			}
+
+			start, end, err := captureRange[Weekday](p, t, tokenWeekday, "day-of-week")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if end != nil {
+				e.Range = &WeekdayRange{Start: start, End: end}
+			} else {
+				e.Wday = start
+			}
+
+			if e.Bottom, err = p.bottom("day-of-week"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.unexpected(t, "day-of-week")
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) yearField() (*YearField, error) {
+	v := &YearField{}
+
+	for {
+		e := &YearExp{}
+		t := p.next()
+
+		switch {
+		case t.is('*'):
+			e.Wildcard = true
+		case t.kind == tokenNumber:
+			start, end, err := captureRange[Year](p, t, tokenNumber, "year")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if end != nil {
+				e.Range = &YearRange{Start: start, End: end}
+			} else {
+				e.Number = start
+			}
+		default:
+			return nil, p.unexpected(t, "year")
+		}
+
+		var err error
+
+		if e.Bottom, err = p.bottom("year"); err != nil {
+			return nil, err
+		}
+
+		v.Exps = append(v.Exps, e)
+
+		if !p.accept(',') {
+			return v, nil
+		}
+	}
+}
+
+func prefixLen(s string, f func(byte) bool) int {
+	n := 0
+
+	for n < len(s) && f(s[n]) {
+		n++
+	}
+
+	return n
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isSpace reports whether c matches `\s`.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
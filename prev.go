@@ -0,0 +1,130 @@
+package cronplan
+
+import (
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+func (v *Expression) Prev(from time.Time) time.Time {
+	schedule := v.PrevN(from, 1)
+
+	if len(schedule) == 0 {
+		return time.Time{}
+	}
+
+	return schedule[0]
+}
+
+func (v *Expression) PrevN(from time.Time, n int) []time.Time {
+	return v.prev0(from, time.Time{}, n)
+}
+
+func (v *Expression) prev0(from time.Time, to time.Time, n int) []time.Time {
+	if to.IsZero() && n < 1 {
+		return []time.Time{}
+	}
+
+	if !to.IsZero() && (from.Equal(to) || from.Before(to)) {
+		return []time.Time{}
+	}
+
+	years := []int{}
+
+	for year := from.Year(); year >= 1970; year-- {
+		t := time.Date(year, 1, 1, 0, 0, 0, 0, from.Location())
+
+		if v.Year.Match(t) {
+			years = append(years, year)
+		}
+	}
+
+	if len(years) == 0 {
+		return []time.Time{}
+	}
+
+	months := v.candidateMonths(from)
+
+	if len(months) == 0 {
+		return []time.Time{}
+	}
+
+	hours := v.candidateHours(from)
+
+	if len(hours) == 0 {
+		return []time.Time{}
+	}
+
+	minutes := v.candidateMinutes(from)
+
+	if len(minutes) == 0 {
+		return []time.Time{}
+	}
+
+	var DayMatch func(time.Time) bool
+
+	if !v.DayOfMonth.Any && v.DayOfWeek.Any {
+		DayMatch = v.DayOfMonth.Match
+	} else if v.DayOfMonth.Any && !v.DayOfWeek.Any {
+		DayMatch = v.DayOfWeek.Match
+	} else {
+		return []time.Time{}
+	}
+
+	schedule := []time.Time{}
+
+YEAR:
+	for _, year := range years {
+		for i := len(months) - 1; i >= 0; i-- {
+			month := months[i]
+
+			if year == from.Year() && month > from.Month() {
+				continue
+			}
+
+			lom := util.LastOfMonth(time.Date(year, month, 1, 0, 0, 0, 0, from.Location()))
+
+			for day := lom; day >= 1; day-- {
+				if year == from.Year() && month == from.Month() && day > from.Day() {
+					continue
+				}
+
+				dayOfMonth := time.Date(year, month, day, 0, 0, 0, 0, from.Location())
+
+				if !DayMatch(dayOfMonth) {
+					continue
+				}
+
+				for j := len(hours) - 1; j >= 0; j-- {
+					hour := hours[j]
+
+					if year == from.Year() && month == from.Month() && day == from.Day() && hour > from.Hour() {
+						continue
+					}
+
+					for k := len(minutes) - 1; k >= 0; k-- {
+						minute := minutes[k]
+
+						if year == from.Year() && month == from.Month() && day == from.Day() && hour == from.Hour() && minute > from.Minute() {
+							continue
+						}
+
+						tm := time.Date(year, month, day, hour, minute, 0, 0, from.Location())
+
+						if !to.IsZero() && tm.Before(to) {
+							break YEAR
+						}
+
+						schedule = append(schedule, tm)
+
+						if to.IsZero() && len(schedule) >= n {
+							break YEAR
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return schedule
+}
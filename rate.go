@@ -0,0 +1,208 @@
+package cronplan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var rateRegexp = regexp.MustCompile(`^rate\(\s*(\d+)\s+(minutes?|hours?|days?)\s*\)$`)
+
+const (
+	RateMinute = "minute"
+	RateHour   = "hour"
+	RateDay    = "day"
+)
+
+// Rate is a rate expression (e.g. "rate(10 minutes)").
+type Rate struct {
+	Value int
+	// Unit is RateMinute, RateHour or RateDay.
+	Unit string
+}
+
+// ParseRate parses a rate expression.
+// Like EventBridge, the unit must be singular for 1 and plural otherwise.
+func ParseRate(exp string) (*Rate, error) {
+	m := rateRegexp.FindStringSubmatch(strings.TrimSpace(exp))
+
+	if m == nil {
+		return nil, fmt.Errorf("invalid rate expression '%s'", exp)
+	}
+
+	n, err := strconv.Atoi(m[1])
+
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("rate value must be a positive integer (value=%s)", m[1])
+	}
+
+	unit := strings.TrimSuffix(m[2], "s")
+
+	if (n == 1) != (unit == m[2]) {
+		return nil, fmt.Errorf("rate unit must be '%s' for %d", (&Rate{Value: n, Unit: unit}).unit(), n)
+	}
+
+	return &Rate{Value: n, Unit: unit}, nil
+}
+
+func (r *Rate) unit() string {
+	if r.Value == 1 {
+		return r.Unit
+	}
+
+	return r.Unit + "s"
+}
+
+func (r *Rate) String() string {
+	return fmt.Sprintf("rate(%d %s)", r.Value, r.unit())
+}
+
+func (r *Rate) Duration() time.Duration {
+	switch r.Unit {
+	case RateHour:
+		return time.Duration(r.Value) * time.Hour
+	case RateDay:
+		return time.Duration(r.Value) * 24 * time.Hour
+	default:
+		return time.Duration(r.Value) * time.Minute
+	}
+}
+
+// ToCron returns the cron expressions that fire at the rate.
+// A rate is anchored at the time it is created, while cron expressions are anchored at 00:00 UTC.
+// If the rate does not divide a day (or a month for days) evenly, the expressions restart
+// at every anchor and the note describes the uneven gap. Otherwise, the note is empty.
+// A rate of more than 15 days returns an error, because the expression would restart
+// on the 1st of every month before the second run.
+func (r *Rate) ToCron() ([]*Expression, string, error) {
+	d := r.Duration()
+
+	if d > 24*time.Hour && d%(24*time.Hour) != 0 {
+		return nil, "", fmt.Errorf("%s cannot be converted to cron expressions", r)
+	}
+
+	if d >= 24*time.Hour {
+		switch days := int(d / (24 * time.Hour)); days {
+		case 1:
+			return []*Expression{mustParse("0 0 * * ? *")}, "", nil
+		case 7:
+			return []*Expression{mustParse("0 0 ? * SUN *")}, "", nil
+		default:
+			if days > 15 {
+				return nil, "", fmt.Errorf("%s cannot be converted to cron expressions: the expression would restart on the 1st of every month before the second run", r)
+			}
+
+			note := fmt.Sprintf("%s does not divide a month evenly: the expression restarts on the 1st of every month", r)
+			return []*Expression{mustParse(fmt.Sprintf("0 0 1/%d * ? *", days))}, note, nil
+		}
+	}
+
+	step := int(d / time.Minute)
+	tods := []int{}
+
+	for tod := 0; tod < 24*60; tod += step {
+		tods = append(tods, tod)
+	}
+
+	exprs := []*Expression{}
+
+	for _, times := range timesStrings(tods) {
+		exprs = append(exprs, mustParse(times+" * * ? *"))
+	}
+
+	note := ""
+
+	if (24*60)%step != 0 {
+		note = fmt.Sprintf("%s does not divide a day evenly: the expressions restart at 00:00 every day, so the gap before 00:00 is %s",
+			r, time.Duration(24*60-tods[len(tods)-1])*time.Minute)
+	}
+
+	return exprs, note, nil
+}
+
+// Period returns the gap between the occurrences if they are exactly periodic.
+// See MinInterval for how the occurrences are evaluated.
+func (v *Expression) Period() (time.Duration, bool) {
+	min, max := v.intervals()
+
+	if min == nil || min.Duration != max.Duration {
+		return 0, false
+	}
+
+	return min.Duration, true
+}
+
+// ToRate returns the rate expression equivalent to the expression.
+// Note that the rate is anchored at the time it is created.
+func (v *Expression) ToRate() (*Rate, error) {
+	period, ok := v.Period()
+
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not periodic", v)
+	}
+
+	for year := minYear; year <= maxYear; year++ {
+		if !v.Year.Match(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			return nil, fmt.Errorf("'%s' is limited to some years", v)
+		}
+	}
+
+	if period%(24*time.Hour) == 0 {
+		return &Rate{Value: int(period / (24 * time.Hour)), Unit: RateDay}, nil
+	} else if period%time.Hour == 0 {
+		return &Rate{Value: int(period / time.Hour), Unit: RateHour}, nil
+	}
+
+	return &Rate{Value: int(period / time.Minute), Unit: RateMinute}, nil
+}
+
+// timesStrings formats sorted minutes of the day as the minute and hour fields,
+// grouping the hours that have the same minutes.
+func timesStrings(tods []int) []string {
+	minutesByHour := map[int][]int{}
+
+	for _, tod := range tods {
+		minutesByHour[tod/60] = append(minutesByHour[tod/60], tod%60)
+	}
+
+	keys := []string{}
+	hours := map[string][]int{}
+
+	for h := 0; h <= 23; h++ {
+		minutes, ok := minutesByHour[h]
+
+		if !ok {
+			continue
+		}
+
+		sort.Ints(minutes)
+		k := stepString(minutes, 0, 59, 59)
+
+		if _, ok := hours[k]; !ok {
+			keys = append(keys, k)
+		}
+
+		hours[k] = append(hours[k], h)
+	}
+
+	ss := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		ss = append(ss, k+" "+stepString(hours[k], 0, 23, 23))
+	}
+
+	return ss
+}
+
+func mustParse(exp string) *Expression {
+	cron, err := Parse(exp)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return cron
+}
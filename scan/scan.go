@@ -0,0 +1,102 @@
+// Package scan extracts schedule expressions from Terraform, CloudFormation, SAM, Serverless Framework
+// and Kubernetes files.
+package scan
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SourceTerraform      = "terraform"
+	SourceCloudFormation = "cloudformation"
+	SourceSAM            = "sam"
+	SourceServerless     = "serverless"
+	SourceKubernetes     = "kubernetes"
+)
+
+// Schedule is a schedule expression found in a file.
+type Schedule struct {
+	File string
+	Line int
+	// Resource is the name of the resource that has the expression,
+	// e.g. "aws_cloudwatch_event_rule.nightly", a logical ID, "Function.Event" for SAM, a function name for Serverless
+	// or "namespace/name" for a Kubernetes CronJob.
+	Resource string
+	// Source is SourceTerraform, SourceCloudFormation, SourceSAM, SourceServerless or SourceKubernetes.
+	Source string
+	// Expression is the expression as written, e.g. "cron(0 10 * * ? *)", "rate(5 minutes)"
+	// or "0 10 * * *" for a Kubernetes CronJob.
+	Expression string
+	// Timezone is the time zone to evaluate the expression in. It is empty if not set.
+	Timezone string
+}
+
+// Parse extracts the schedule expressions from the file content.
+// The format is chosen by the file extension: ".tf" and ".tf.json" are Terraform,
+// and ".yml", ".yaml", ".json" and ".template" are CloudFormation, SAM, Serverless or Kubernetes.
+// Expressions that are not string literals (e.g. variables and intrinsic functions) are skipped.
+func Parse(filename string, src []byte) ([]*Schedule, error) {
+	switch {
+	case strings.HasSuffix(filename, ".tf"):
+		return parseHCL(filename, src, false)
+	case strings.HasSuffix(filename, ".tf.json"):
+		return parseHCL(filename, src, true)
+	case strings.HasSuffix(filename, ".yml"), strings.HasSuffix(filename, ".yaml"),
+		strings.HasSuffix(filename, ".json"), strings.HasSuffix(filename, ".template"):
+		return parseYAML(filename, src)
+	}
+
+	return nil, nil
+}
+
+// File extracts the schedule expressions from the file.
+func File(path string) ([]*Schedule, error) {
+	src, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(path, src)
+}
+
+// Dir walks the directory and extracts the schedule expressions from the files.
+// Hidden directories and "node_modules" are skipped.
+// A file that cannot be parsed does not stop the walk: the errors are joined and returned with the schedules.
+func Dir(root string) ([]*Schedule, error) {
+	schedules := []*Schedule{}
+	errs := []error{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		ss, err := File(path)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		schedules = append(schedules, ss...)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package scan
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// terraformResources are the attributes of the expression and the time zone for each resource type.
+var terraformResources = map[string][2]string{
+	"aws_cloudwatch_event_rule": {"schedule_expression", ""},
+	"aws_scheduler_schedule":    {"schedule_expression", "schedule_expression_timezone"},
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+	},
+}
+
+var terraformResourceSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "schedule_expression"},
+		{Name: "schedule_expression_timezone"},
+	},
+}
+
+func parseHCL(filename string, src []byte, json bool) ([]*Schedule, error) {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+
+	if json {
+		file, diags = hcljson.Parse(src, filename)
+	} else {
+		file, diags = hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	content, _, diags := file.Body.PartialContent(terraformSchema)
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	schedules := []*Schedule{}
+
+	for _, block := range content.Blocks {
+		attrs, ok := terraformResources[block.Labels[0]]
+
+		if !ok {
+			continue
+		}
+
+		resource, _, diags := block.Body.PartialContent(terraformResourceSchema)
+
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		attr, ok := resource.Attributes[attrs[0]]
+
+		if !ok {
+			continue
+		}
+
+		exp, ok := stringLiteral(attr)
+
+		if !ok {
+			continue
+		}
+
+		schedule := &Schedule{
+			File:       filename,
+			Line:       attr.Expr.Range().Start.Line,
+			Resource:   block.Labels[0] + "." + block.Labels[1],
+			Source:     SourceTerraform,
+			Expression: exp,
+		}
+
+		if attr, ok := resource.Attributes[attrs[1]]; ok {
+			schedule.Timezone, _ = stringLiteral(attr)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// stringLiteral returns the value of the attribute if it can be evaluated without variables.
+func stringLiteral(attr *hcl.Attribute) (string, bool) {
+	v, diags := attr.Expr.Value(nil)
+
+	if diags.HasErrors() || v.IsNull() || !v.IsKnown() || v.Type() != cty.String {
+		return "", false
+	}
+
+	return v.AsString(), true
+}
@@ -0,0 +1,245 @@
+package scan
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAML parses YAML or JSON documents as CloudFormation (including SAM) templates, Serverless Framework configs
+// or Kubernetes manifests.
+// Documents that are neither are ignored.
+func parseYAML(filename string, src []byte) ([]*Schedule, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	schedules := []*Schedule{}
+
+	for {
+		doc := &yaml.Node{}
+
+		if err := dec.Decode(doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		if lookup(doc, "apiVersion") != nil && lookup(doc, "kind") != nil {
+			schedules = append(schedules, kubernetesSchedules(filename, doc)...)
+		} else if functions := lookup(doc, "functions"); functions != nil && lookup(doc, "service") != nil {
+			schedules = append(schedules, serverlessSchedules(filename, functions)...)
+			// CloudFormation resources in serverless.yml
+			schedules = append(schedules, cfnSchedules(filename, lookup(lookup(doc, "resources"), "Resources"))...)
+		} else {
+			schedules = append(schedules, cfnSchedules(filename, lookup(doc, "Resources"))...)
+		}
+	}
+
+	return schedules, nil
+}
+
+// lookup returns the value of the key in the mapping node, or nil.
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	for node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		if node.Kind == yaml.DocumentNode {
+			if len(node.Content) == 0 {
+				return nil
+			}
+
+			node = node.Content[0]
+		} else {
+			node = node.Alias
+		}
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			v := node.Content[i+1]
+
+			if v.Kind == yaml.AliasNode {
+				v = v.Alias
+			}
+
+			return v
+		}
+	}
+
+	return nil
+}
+
+// entries returns the key-value pairs of the mapping node in order.
+func entries(node *yaml.Node) [][2]*yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	kvs := [][2]*yaml.Node{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		v := node.Content[i+1]
+
+		if v.Kind == yaml.AliasNode {
+			v = v.Alias
+		}
+
+		kvs = append(kvs, [2]*yaml.Node{node.Content[i], v})
+	}
+
+	return kvs
+}
+
+// str returns the value of a plain string scalar. Tagged scalars (e.g. "!Sub") are not literals.
+func str(node *yaml.Node) (string, bool) {
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return "", false
+	}
+
+	return strings.TrimSpace(node.Value), true
+}
+
+func cfnSchedules(filename string, resources *yaml.Node) []*Schedule {
+	schedules := []*Schedule{}
+
+	for _, kv := range entries(resources) {
+		name := kv[0].Value
+		typ, _ := str(lookup(kv[1], "Type"))
+		props := lookup(kv[1], "Properties")
+
+		switch typ {
+		case "AWS::Events::Rule", "AWS::Scheduler::Schedule":
+			schedule := newYAMLSchedule(filename, name, SourceCloudFormation, lookup(props, "ScheduleExpression"), lookup(props, "ScheduleExpressionTimezone"))
+
+			if schedule != nil {
+				schedules = append(schedules, schedule)
+			}
+		case "AWS::Serverless::Function", "AWS::Serverless::StateMachine":
+			for _, event := range entries(lookup(props, "Events")) {
+				eventProps := lookup(event[1], "Properties")
+				var schedule *Schedule
+
+				switch typ, _ := str(lookup(event[1], "Type")); typ {
+				case "Schedule":
+					schedule = newYAMLSchedule(filename, name+"."+event[0].Value, SourceSAM, lookup(eventProps, "Schedule"), nil)
+				case "ScheduleV2":
+					schedule = newYAMLSchedule(filename, name+"."+event[0].Value, SourceSAM, lookup(eventProps, "ScheduleExpression"), lookup(eventProps, "ScheduleExpressionTimezone"))
+				}
+
+				if schedule != nil {
+					schedules = append(schedules, schedule)
+				}
+			}
+		}
+	}
+
+	return schedules
+}
+
+func serverlessSchedules(filename string, functions *yaml.Node) []*Schedule {
+	schedules := []*Schedule{}
+
+	for _, fn := range entries(functions) {
+		var fnSchedules []*Schedule
+		events := lookup(fn[1], "events")
+
+		if events == nil || events.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, event := range events.Content {
+			node := lookup(event, "schedule")
+
+			if node == nil {
+				continue
+			}
+
+			// schedule: rate(10 minutes)
+			if schedule := newYAMLSchedule(filename, fn[0].Value, SourceServerless, node, nil); schedule != nil {
+				fnSchedules = append(fnSchedules, schedule)
+				continue
+			}
+
+			// schedule: { rate: [cron(...), ...], timezone: ... }
+			rate := lookup(node, "rate")
+			rates := []*yaml.Node{rate}
+
+			if rate != nil && rate.Kind == yaml.SequenceNode {
+				rates = rate.Content
+			}
+
+			for _, r := range rates {
+				if schedule := newYAMLSchedule(filename, fn[0].Value, SourceServerless, r, lookup(node, "timezone")); schedule != nil {
+					fnSchedules = append(fnSchedules, schedule)
+				}
+			}
+		}
+
+		// number the schedules of a function if it has more than one
+		if len(fnSchedules) > 1 {
+			for i, s := range fnSchedules {
+				s.Resource = fmt.Sprintf("%s.%d", s.Resource, i+1)
+			}
+		}
+
+		schedules = append(schedules, fnSchedules...)
+	}
+
+	return schedules
+}
+
+// kubernetesSchedules returns the schedules of a CronJob or of the CronJobs in a list.
+func kubernetesSchedules(filename string, obj *yaml.Node) []*Schedule {
+	schedules := []*Schedule{}
+
+	if kind, _ := str(lookup(obj, "kind")); kind != "CronJob" {
+		if items := lookup(obj, "items"); items != nil && items.Kind == yaml.SequenceNode {
+			for _, item := range items.Content {
+				schedules = append(schedules, kubernetesSchedules(filename, item)...)
+			}
+		}
+
+		return schedules
+	}
+
+	name, _ := str(lookup(lookup(obj, "metadata"), "name"))
+
+	if ns, _ := str(lookup(lookup(obj, "metadata"), "namespace")); ns != "" {
+		name = ns + "/" + name
+	}
+
+	spec := lookup(obj, "spec")
+
+	if schedule := newYAMLSchedule(filename, name, SourceKubernetes, lookup(spec, "schedule"), lookup(spec, "timeZone")); schedule != nil {
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules
+}
+
+func newYAMLSchedule(filename string, resource string, source string, exp *yaml.Node, tz *yaml.Node) *Schedule {
+	s, ok := str(exp)
+
+	if !ok {
+		return nil
+	}
+
+	schedule := &Schedule{
+		File:       filename,
+		Line:       exp.Line,
+		Resource:   resource,
+		Source:     source,
+		Expression: s,
+	}
+
+	schedule.Timezone, _ = str(tz)
+	return schedule
+}
@@ -0,0 +1,332 @@
+package cronplan
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+)
+
+const (
+	minYear = 1970
+	maxYear = 2199
+)
+
+// idSet is a bitset of expression ids.
+type idSet []uint64
+
+func (s *idSet) add(id int) {
+	i := id / 64
+
+	for len(*s) <= i {
+		*s = append(*s, 0)
+	}
+
+	(*s)[i] |= 1 << (id % 64)
+}
+
+func (s idSet) del(id int) {
+	if i := id / 64; i < len(s) {
+		s[i] &^= 1 << (id % 64)
+	}
+}
+
+func (s idSet) empty() bool {
+	for _, w := range s {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s idSet) each(f func(id int)) {
+	for i, w := range s {
+		for w != 0 {
+			b := bits.TrailingZeros64(w)
+			f(i*64 + b)
+			w &^= 1 << b
+		}
+	}
+}
+
+func intersect(dst idSet, a idSet, b idSet) idSet {
+	n := min(len(a), len(b))
+	dst = dst[:0]
+
+	for i := 0; i < n; i++ {
+		dst = append(dst, a[i]&b[i])
+	}
+
+	return dst
+}
+
+// ExpressionSet holds named expressions in an inverted index keyed on
+// the values of each field, so that the expressions firing at a given
+// time can be found without matching each one.
+// It is not safe for concurrent use.
+type ExpressionSet struct {
+	ids     map[string]int
+	names   []string
+	exprs   []*Expression
+	free    []int
+	minutes [60]idSet
+	hours   [24]idSet
+	days    [31]idSet
+	wdays   [7]idSet
+	months  [12]idSet
+	years   [maxYear - minYear + 1]idSet
+	// complex holds the ids of the expressions whose day-of-month or day-of-week
+	// depends on the calendar of the month (e.g. "L", "W", "#").
+	// They are indexed on every day and verified with Match.
+	complex idSet
+}
+
+type SetOccurrence struct {
+	Time  time.Time
+	Names []string
+}
+
+func NewExpressionSet() *ExpressionSet {
+	return &ExpressionSet{
+		ids: map[string]int{},
+	}
+}
+
+func (s *ExpressionSet) Len() int {
+	return len(s.ids)
+}
+
+func (s *ExpressionSet) Get(name string) (*Expression, bool) {
+	id, ok := s.ids[name]
+
+	if !ok {
+		return nil, false
+	}
+
+	return s.exprs[id], true
+}
+
+// Add adds an expression to the set. An expression with the same name is replaced.
+func (s *ExpressionSet) Add(name string, expr *Expression) {
+	s.Remove(name)
+
+	var id int
+
+	if n := len(s.free); n > 0 {
+		id = s.free[n-1]
+		s.free = s.free[:n-1]
+		s.names[id] = name
+		s.exprs[id] = expr
+	} else {
+		id = len(s.names)
+		s.names = append(s.names, name)
+		s.exprs = append(s.exprs, expr)
+	}
+
+	s.ids[name] = id
+
+	for minute := 0; minute <= 59; minute++ {
+		if expr.Minute.Match(time.Date(2000, 1, 1, 0, minute, 0, 0, time.UTC)) {
+			s.minutes[minute].add(id)
+		}
+	}
+
+	for hour := 0; hour <= 23; hour++ {
+		if expr.Hour.Match(time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC)) {
+			s.hours[hour].add(id)
+		}
+	}
+
+	if isComplexDay(expr) {
+		s.complex.add(id)
+
+		for i := range s.days {
+			s.days[i].add(id)
+		}
+
+		for i := range s.wdays {
+			s.wdays[i].add(id)
+		}
+	} else {
+		for day := 1; day <= 31; day++ {
+			if expr.DayOfMonth.Match(time.Date(2000, 1, day, 0, 0, 0, 0, time.UTC)) {
+				s.days[day-1].add(id)
+			}
+		}
+
+		// 2000-01-02 is Sunday
+		for wday := time.Sunday; wday <= time.Saturday; wday++ {
+			if expr.DayOfWeek.Match(time.Date(2000, 1, 2+int(wday), 0, 0, 0, 0, time.UTC)) {
+				s.wdays[wday].add(id)
+			}
+		}
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if expr.Month.Match(time.Date(2000, month, 1, 0, 0, 0, 0, time.UTC)) {
+			s.months[month-1].add(id)
+		}
+	}
+
+	for year := minYear; year <= maxYear; year++ {
+		if expr.Year.Match(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			s.years[year-minYear].add(id)
+		}
+	}
+}
+
+func (s *ExpressionSet) Remove(name string) bool {
+	id, ok := s.ids[name]
+
+	if !ok {
+		return false
+	}
+
+	for i := range s.minutes {
+		s.minutes[i].del(id)
+	}
+
+	for i := range s.hours {
+		s.hours[i].del(id)
+	}
+
+	for i := range s.days {
+		s.days[i].del(id)
+	}
+
+	for i := range s.wdays {
+		s.wdays[i].del(id)
+	}
+
+	for i := range s.months {
+		s.months[i].del(id)
+	}
+
+	for i := range s.years {
+		s.years[i].del(id)
+	}
+
+	s.complex.del(id)
+
+	delete(s.ids, name)
+	s.names[id] = ""
+	s.exprs[id] = nil
+	s.free = append(s.free, id)
+
+	return true
+}
+
+func isComplexDay(expr *Expression) bool {
+	for _, e := range expr.DayOfMonth.Exps {
+		if e.NearestWeekday != nil || e.LastWeekday != nil || e.Last != nil {
+			return true
+		}
+	}
+
+	for _, e := range expr.DayOfWeek.Exps {
+		if e.Nth != nil || e.Last != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// daySet narrows set down to the ids of the expressions that fire on the day of t.
+func (s *ExpressionSet) daySet(set idSet, t time.Time) idSet {
+	set = intersect(set, set, s.days[t.Day()-1])
+	set = intersect(set, set, s.wdays[t.Weekday()])
+	set = intersect(set, set, s.months[t.Month()-1])
+	set = intersect(set, set, s.years[t.Year()-minYear])
+
+	intersect(nil, set, s.complex).each(func(id int) {
+		expr := s.exprs[id]
+
+		if !expr.DayOfMonth.Match(t) || !expr.DayOfWeek.Match(t) {
+			set.del(id)
+		}
+	})
+
+	return set
+}
+
+func (s *ExpressionSet) namesOf(set idSet) []string {
+	names := []string{}
+
+	set.each(func(id int) {
+		names = append(names, s.names[id])
+	})
+
+	sort.Strings(names)
+
+	return names
+}
+
+// At returns the names of the expressions that fire at t, sorted by name.
+func (s *ExpressionSet) At(t time.Time) []string {
+	if t.Year() < minYear || maxYear < t.Year() {
+		return []string{}
+	}
+
+	set := intersect(nil, s.minutes[t.Minute()], s.hours[t.Hour()])
+	set = s.daySet(set, t)
+
+	return s.namesOf(set)
+}
+
+// Between returns the names of the expressions that fire between from and to, grouped by minute.
+func (s *ExpressionSet) Between(from time.Time, to time.Time) []SetOccurrence {
+	schedule := []SetOccurrence{}
+
+	if !from.Before(to) {
+		return schedule
+	}
+
+	loc := from.Location()
+	start := time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), 0, 0, loc)
+	var daySet, hourSet, minuteSet idSet
+
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); !day.After(to); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc) {
+		if day.Year() < minYear {
+			continue
+		} else if day.Year() > maxYear {
+			break
+		}
+
+		daySet = append(daySet[:0], s.days[day.Day()-1]...)
+		daySet = s.daySet(daySet, day)
+
+		if daySet.empty() {
+			continue
+		}
+
+		for hour := 0; hour <= 23; hour++ {
+			hourSet = intersect(hourSet, daySet, s.hours[hour])
+
+			if hourSet.empty() {
+				continue
+			}
+
+			for minute := 0; minute <= 59; minute++ {
+				minuteSet = intersect(minuteSet, hourSet, s.minutes[minute])
+
+				if minuteSet.empty() {
+					continue
+				}
+
+				tm := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+
+				if tm.Before(start) {
+					continue
+				} else if tm.After(to) {
+					return schedule
+				}
+
+				schedule = append(schedule, SetOccurrence{Time: tm, Names: s.namesOf(minuteSet)})
+			}
+		}
+	}
+
+	return schedule
+}
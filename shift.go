@@ -0,0 +1,765 @@
+package cronplan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+const maxShiftErrorTimes = 100
+
+// ShiftError is returned by ShiftZone when an expression has no exact form in the target time zone.
+type ShiftError struct {
+	Expr string
+	From *time.Location
+	To   *time.Location
+	// Times holds the occurrences that the shifted expressions would not reproduce,
+	// in the source time zone, starting from now (at most 100).
+	Times []time.Time
+	// Extra holds the times at which the shifted expressions would fire but the expression does not,
+	// in the source time zone, starting from now (at most 100).
+	Extra []time.Time
+}
+
+func (e *ShiftError) Error() string {
+	details := []string{}
+
+	if len(e.Times) > 0 {
+		details = append(details, "affected occurrences: "+shiftTimesString(e.Times))
+	}
+
+	if len(e.Extra) > 0 {
+		details = append(details, "extra occurrences: "+shiftTimesString(e.Extra))
+	}
+
+	return fmt.Sprintf("'%s' has no exact form in %s (%s)", e.Expr, e.To, strings.Join(details, "; "))
+}
+
+func shiftTimesString(times []time.Time) string {
+	ss := make([]string, 0, 4)
+
+	for i, t := range times {
+		if i >= 3 {
+			ss = append(ss, "...")
+			break
+		}
+
+		ss = append(ss, t.Format(time.RFC3339))
+	}
+
+	return strings.Join(ss, ", ")
+}
+
+type shiftGroup struct {
+	carry int
+	src   []int
+	dst   []int
+}
+
+type dayPart struct {
+	dom   string
+	month string
+	dow   string
+	year  string
+	expr  *Expression
+}
+
+func (p *dayPart) String() string {
+	return fmt.Sprintf("%s %s %s %s", p.dom, p.month, p.dow, p.year)
+}
+
+// ShiftZone rewrites an expression evaluated in the from time zone into expressions
+// evaluated in the to time zone that fire at the same instants.
+// The hours and minutes are shifted by the offset between the time zones, and the days
+// that move across midnight are carried to the previous or next day, weekday and month.
+// If there is no exact form (e.g. the offset changes with DST, or "LW" or "#" moves to
+// another day), a *ShiftError listing the affected occurrences is returned.
+//
+// The offset between the time zones changes over time (DST and changes of the zone rules),
+// so now is the start of the one-year window in which the offset is taken and checked.
+// Pass time.Now() to rewrite for the current rules, or a fixed time for a reproducible result.
+// The occurrences in a *ShiftError are also listed from now. The days are checked for all years.
+func ShiftZone(expr *Expression, from *time.Location, to *time.Location, now time.Time) ([]*Expression, error) {
+	tods := expr.timesOfDay()
+
+	if len(tods) == 0 {
+		return nil, fmt.Errorf("'%s' never fires", expr)
+	}
+
+	delta, affected := expr.zoneDelta(tods, from, to, now)
+
+	if len(affected) > 0 {
+		return nil, &ShiftError{Expr: expr.String(), From: from, To: to, Times: affected}
+	}
+
+	if delta%60 != 0 {
+		return nil, fmt.Errorf("offset between %s and %s is not a multiple of a minute", from, to)
+	}
+
+	groups := map[int]*shiftGroup{}
+	carries := []int{}
+
+	for _, tod := range tods {
+		shifted := tod + delta/60
+		carry := shifted / 1440
+
+		if shifted < 0 {
+			carry = (shifted - 1439) / 1440
+		}
+
+		g, ok := groups[carry]
+
+		if !ok {
+			g = &shiftGroup{carry: carry}
+			groups[carry] = g
+			carries = append(carries, carry)
+		}
+
+		g.src = append(g.src, tod)
+		g.dst = append(g.dst, shifted-carry*1440)
+	}
+
+	sort.Ints(carries)
+	missingDays := map[int][]time.Time{}
+	extraDays := map[int][]time.Time{}
+	parts := map[int][]*dayPart{}
+
+	for _, carry := range carries {
+		ps, err := expr.shiftDays(carry, false)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var missing, extra []time.Time
+
+		if carry != 0 {
+			missing, extra = expr.verifyDays(ps, carry)
+
+			// the days that move to another month may need the day-of-month for each length of the months
+			if len(missing) > 0 || len(extra) > 0 {
+				ps, err = expr.shiftDays(carry, true)
+
+				if err != nil {
+					return nil, err
+				}
+
+				missing, extra = expr.verifyDays(ps, carry)
+			}
+		}
+
+		parts[carry] = ps
+		missingDays[carry] = missing
+		extraDays[carry] = extra
+	}
+
+	// occurrences returns the times of the days in the source time zone, starting from now
+	occurrences := func(days map[int][]time.Time) []time.Time {
+		times := []time.Time{}
+
+		for _, carry := range carries {
+			for _, day := range days[carry] {
+				for _, tod := range groups[carry].src {
+					times = append(times, time.Date(day.Year(), day.Month(), day.Day(), tod/60, tod%60, 0, 0, from))
+				}
+			}
+		}
+
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		i := sort.Search(len(times), func(i int) bool { return !times[i].Before(now) })
+
+		if i == len(times) {
+			i = 0
+		}
+
+		return times[i:min(i+maxShiftErrorTimes, len(times))]
+	}
+
+	if missing, extra := occurrences(missingDays), occurrences(extraDays); len(missing) > 0 || len(extra) > 0 {
+		return nil, &ShiftError{Expr: expr.String(), From: from, To: to, Times: missing, Extra: extra}
+	}
+
+	// merge the shifted times that share the days, then the hours that share the minutes
+	days := []string{}
+	minutesByDays := map[string]map[int][]int{}
+
+	for _, carry := range carries {
+		for _, p := range parts[carry] {
+			k := p.String()
+
+			if _, ok := minutesByDays[k]; !ok {
+				days = append(days, k)
+				minutesByDays[k] = map[int][]int{}
+			}
+
+			for _, tod := range groups[carry].dst {
+				minutesByDays[k][tod/60] = append(minutesByDays[k][tod/60], tod%60)
+			}
+		}
+	}
+
+	type key struct {
+		minutes string
+		days    string
+	}
+
+	keys := []key{}
+	hours := map[key][]int{}
+
+	for _, d := range days {
+		for h := 0; h <= 23; h++ {
+			minutes, ok := minutesByDays[d][h]
+
+			if !ok {
+				continue
+			}
+
+			sort.Ints(minutes)
+			k := key{minutes: stepString(minutes, 0, 59, 59), days: d}
+
+			if _, ok := hours[k]; !ok {
+				keys = append(keys, k)
+			}
+
+			hours[k] = append(hours[k], h)
+		}
+	}
+
+	exprs := make([]*Expression, 0, len(keys))
+
+	for _, k := range keys {
+		cron, err := Parse(fmt.Sprintf("%s %s %s", k.minutes, stepString(hours[k], 0, 23, 23), k.days))
+
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, cron)
+	}
+
+	return exprs, nil
+}
+
+// timesOfDay returns the minutes of the day at which the expression fires.
+func (v *Expression) timesOfDay() []int {
+	tods := []int{}
+
+	for hour := 0; hour <= 23; hour++ {
+		if !v.Hour.Match(time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC)) {
+			continue
+		}
+
+		for minute := 0; minute <= 59; minute++ {
+			if v.Minute.Match(time.Date(2000, 1, 1, 0, minute, 0, 0, time.UTC)) {
+				tods = append(tods, hour*60+minute)
+			}
+		}
+	}
+
+	return tods
+}
+
+func (v *Expression) matchDay(t time.Time) bool {
+	return v.DayOfMonth.Match(t) &&
+		v.Month.Match(t) &&
+		v.DayOfWeek.Match(t) &&
+		v.Year.Match(t)
+}
+
+// zoneDelta returns the offset of the to time zone from the from time zone in seconds
+// for most occurrences in the year from now, and the occurrences with another offset.
+func (v *Expression) zoneDelta(tods []int, from *time.Location, to *time.Location, now time.Time) (int, []time.Time) {
+	start := now.In(from)
+	end := start.AddDate(1, 0, 0)
+
+	offset := func(t time.Time) int {
+		_, f := t.In(from).Zone()
+		_, o := t.In(to).Zone()
+		return o - f
+	}
+
+	each := func(f func(t time.Time, delta int)) {
+		for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, from); day.Before(end); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, from) {
+			if !v.matchDay(day) {
+				continue
+			}
+
+			for _, tod := range tods {
+				t := time.Date(day.Year(), day.Month(), day.Day(), tod/60, tod%60, 0, 0, from)
+
+				if t.Before(start) || !t.Before(end) {
+					continue
+				}
+
+				f(t, offset(t))
+			}
+		}
+	}
+
+	counts := map[int]int{}
+
+	each(func(_ time.Time, delta int) {
+		counts[delta]++
+	})
+
+	delta := offset(now)
+
+	for d, n := range counts {
+		if n > counts[delta] || (n == counts[delta] && d < delta) {
+			delta = d
+		}
+	}
+
+	affected := []time.Time{}
+
+	each(func(t time.Time, d int) {
+		if d != delta && len(affected) < maxShiftErrorTimes {
+			affected = append(affected, t)
+		}
+	})
+
+	return delta, affected
+}
+
+// verifyDays returns the days on which the expression fires but the day parts shifted by carry days do not,
+// and the days on which the day parts fire but the expression does not.
+func (v *Expression) verifyDays(parts []*dayPart, carry int) ([]time.Time, []time.Time) {
+	missing := []time.Time{}
+	extra := []time.Time{}
+
+	for day := time.Date(minYear, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() <= maxYear; day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, time.UTC) {
+		shifted := time.Date(day.Year(), day.Month(), day.Day()+carry, 0, 0, 0, 0, time.UTC)
+
+		if shifted.Year() < minYear || maxYear < shifted.Year() {
+			continue
+		}
+
+		matched := false
+
+		for _, p := range parts {
+			if p.expr.matchDay(shifted) {
+				matched = true
+				break
+			}
+		}
+
+		if m := v.matchDay(day); m && !matched {
+			missing = append(missing, day)
+		} else if !m && matched {
+			extra = append(extra, day)
+		}
+	}
+
+	return missing, extra
+}
+
+type domSet struct {
+	fixed [32]bool
+	last  [31]bool
+}
+
+// shiftDays returns the day-of-month, month, day-of-week and year fields shifted by carry days.
+// If perMonth is true, the fixed days are shifted for the length of each month
+// (e.g. the 30th of April moves to the 1st of May), otherwise all months have 31 days.
+func (v *Expression) shiftDays(carry int, perMonth bool) ([]*dayPart, error) {
+	parts := []*dayPart{}
+
+	if carry == 0 || v.everyDay() {
+		parts = append(parts, &dayPart{
+			dom:   v.DayOfMonth.String(),
+			month: v.Month.String(),
+			dow:   v.DayOfWeek.String(),
+			year:  v.Year.String(),
+		})
+	} else if v.DayOfMonth.Any {
+		wdays := []int{}
+
+		for wday := 0; wday <= 6; wday++ {
+			// 2000-01-02 is Sunday
+			t := time.Date(2000, 1, 2+((wday-carry)%7+7)%7, 0, 0, 0, 0, time.UTC)
+
+			for _, e := range v.DayOfWeek.Exps {
+				if e.Nth != nil || (e.Last != nil && e.Last.Wday != nil) {
+					continue
+				}
+
+				if e.Match(t) {
+					wdays = append(wdays, wday)
+					break
+				}
+			}
+		}
+
+		if len(wdays) > 0 {
+			parts = append(parts, &dayPart{
+				dom:   "?",
+				month: v.Month.String(),
+				dow:   listString(wdays, 0, 6, 6, func(i int) string { return util.ShortWeekdayNames[i] }),
+				year:  v.Year.String(),
+			})
+		}
+	} else {
+		months := []int{}
+
+		for month := 1; month <= 12; month++ {
+			if v.Month.Match(time.Date(2000, time.Month(month), 1, 0, 0, 0, 0, time.UTC)) {
+				months = append(months, month)
+			}
+		}
+
+		// sets holds the day-of-month fields by the month offset and the source month
+		sets := map[int]map[int]*domSet{}
+
+		set := func(s int, m int) *domSet {
+			if _, ok := sets[s]; !ok {
+				sets[s] = map[int]*domSet{}
+			}
+
+			if _, ok := sets[s][m]; !ok {
+				sets[s][m] = &domSet{}
+			}
+
+			return sets[s][m]
+		}
+
+		for _, m := range months {
+			lom := 31
+
+			if perMonth {
+				// 2000 is a leap year, so February has the 29th
+				lom = util.DaysIn(2000, time.Month(m))
+			}
+
+			shiftFixed := func(day int) {
+				if day > lom {
+					return
+				}
+
+				if d := day + carry; d >= 1 && d <= lom {
+					set(0, m).fixed[d] = true
+				} else if d > lom {
+					set(1, m).fixed[d-lom] = true
+				} else if -d <= 30 {
+					set(-1, m).last[-d] = true
+				}
+			}
+
+			shiftLast := func(k int) {
+				if j := k - carry; j >= 0 && j <= 30 {
+					set(0, m).last[j] = true
+				} else if j < 0 {
+					set(1, m).fixed[-j] = true
+				}
+			}
+
+			for _, e := range v.DayOfMonth.Exps {
+				if e.NearestWeekday != nil || e.LastWeekday != nil {
+					continue
+				} else if e.Last != nil {
+					shiftLast(e.Last.Int())
+					continue
+				}
+
+				days := []int{}
+
+				for day := 1; day <= 31; day++ {
+					if e.Match(time.Date(2000, 1, day, 0, 0, 0, 0, time.UTC)) {
+						days = append(days, day)
+					}
+				}
+
+				if len(days) == 31 {
+					for day := 1; day <= 28; day++ {
+						shiftFixed(day)
+					}
+
+					for k := 0; k <= 2; k++ {
+						shiftLast(k)
+					}
+				} else {
+					for _, day := range days {
+						shiftFixed(day)
+					}
+				}
+			}
+		}
+
+		for _, s := range []int{-1, 0, 1} {
+			// the source months that have the same days are in one part
+			keys := []domSet{}
+			monthsByDays := map[domSet][]int{}
+
+			for _, m := range months {
+				ds, ok := sets[s][m]
+
+				if !ok {
+					continue
+				}
+
+				if _, ok := monthsByDays[*ds]; !ok {
+					keys = append(keys, *ds)
+				}
+
+				monthsByDays[*ds] = append(monthsByDays[*ds], m)
+			}
+
+			for _, ds := range keys {
+				// the months that cross the year boundary also shift the year
+				var straight, wrapped []int
+
+				for _, m := range monthsByDays[ds] {
+					if v.Year.String() == "*" || (m+s >= 1 && m+s <= 12) {
+						straight = append(straight, (m+s+11)%12+1)
+					} else {
+						wrapped = append(wrapped, (m+s+11)%12+1)
+					}
+				}
+
+				for i, ms := range [][]int{straight, wrapped} {
+					if len(ms) == 0 {
+						continue
+					}
+
+					year := v.Year.String()
+
+					if i == 1 && year != "*" {
+						year = v.shiftYears(s)
+
+						if year == "" {
+							continue
+						}
+					}
+
+					dom := ds.String(ms)
+
+					if dom == "" {
+						continue
+					}
+
+					sort.Ints(ms)
+
+					parts = append(parts, &dayPart{
+						dom:   dom,
+						month: listString(ms, 1, 12, 12, func(i int) string { return util.ShortMonthNames[i-1] }),
+						dow:   "?",
+						year:  year,
+					})
+				}
+			}
+		}
+	}
+
+	// join the day-of-month fields of the parts in the same months
+	merged := []*dayPart{}
+
+	for _, p := range parts {
+		joined := false
+
+		for _, m := range merged {
+			if m.month == p.month && m.dow == p.dow && m.year == p.year {
+				if m.dom != "*" {
+					m.dom += "," + p.dom
+				}
+
+				if p.dom == "*" {
+					m.dom = "*"
+				}
+
+				joined = true
+				break
+			}
+		}
+
+		if !joined {
+			merged = append(merged, p)
+		}
+	}
+
+	parts = merged
+
+	for _, p := range parts {
+		cron, err := Parse("0 0 " + p.String())
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.expr = cron
+	}
+
+	return parts, nil
+}
+
+// everyDay reports whether the expression fires every day.
+func (v *Expression) everyDay() bool {
+	if v.Year.String() != "*" {
+		return false
+	}
+
+	for day := 1; day <= 31; day++ {
+		if !v.DayOfMonth.Match(time.Date(2000, 1, day, 0, 0, 0, 0, time.UTC)) {
+			return false
+		}
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if !v.Month.Match(time.Date(2000, month, 1, 0, 0, 0, 0, time.UTC)) {
+			return false
+		}
+	}
+
+	// 2000-01-02 is Sunday
+	for day := 2; day <= 8; day++ {
+		if !v.DayOfWeek.Match(time.Date(2000, 1, day, 0, 0, 0, 0, time.UTC)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *Expression) shiftYears(s int) string {
+	years := []int{}
+
+	for year := minYear; year <= maxYear; year++ {
+		if y := year + s; y >= minYear && y <= maxYear && v.Year.Match(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			years = append(years, y)
+		}
+	}
+
+	if len(years) == 0 {
+		return ""
+	}
+
+	sort.Ints(years)
+
+	return listString(years, minYear, maxYear, maxYear, strconv.Itoa)
+}
+
+// String returns the day-of-month field in the months.
+func (ds *domSet) String(months []int) string {
+	fixed := ds.fixed
+	last := ds.last
+	lengths := map[int]bool{}
+
+	for _, m := range months {
+		switch time.Month(m) {
+		case time.February:
+			lengths[28] = true
+			lengths[29] = true
+		case time.April, time.June, time.September, time.November:
+			lengths[30] = true
+		default:
+			lengths[31] = true
+		}
+	}
+
+	// the last days are fixed days if all the months have the same length
+	if len(lengths) == 1 {
+		for l := range lengths {
+			for k, ok := range last {
+				if ok {
+					fixed[l-k] = true
+					last[k] = false
+				}
+			}
+		}
+	}
+
+	all := true
+
+	for l := range lengths {
+		for day := 1; day <= l; day++ {
+			if !fixed[day] && !last[l-day] {
+				all = false
+			}
+		}
+	}
+
+	if all {
+		return "*"
+	}
+
+	days := []int{}
+
+	for day := 1; day <= 31; day++ {
+		if fixed[day] {
+			days = append(days, day)
+		}
+	}
+
+	ss := []string{}
+
+	if len(days) > 0 {
+		ss = append(ss, stepString(days, 1, 31, 31))
+	}
+
+	for k := 30; k >= 0; k-- {
+		if last[k] {
+			last := LastDayOfMonth(k)
+			ss = append(ss, last.String())
+		}
+	}
+
+	return strings.Join(ss, ",")
+}
+
+// stepString formats sorted values as a step (e.g. "*/5", "10-50/20") or as a list of ranges.
+func stepString(values []int, lo int, hi int, maxStart int) string {
+	if n := len(values); n >= 3 && n < hi-lo+1 {
+		step := values[1] - values[0]
+		progression := step > 1
+
+		for i := 2; progression && i < n; i++ {
+			if values[i]-values[i-1] != step {
+				progression = false
+			}
+		}
+
+		if progression {
+			if values[n-1]+step <= hi {
+				return fmt.Sprintf("%d-%d/%d", values[0], values[n-1], step)
+			} else if values[0] == lo {
+				return fmt.Sprintf("*/%d", step)
+			} else {
+				return fmt.Sprintf("%d/%d", values[0], step)
+			}
+		}
+	}
+
+	return listString(values, lo, hi, maxStart, strconv.Itoa)
+}
+
+// listString formats sorted values as a list of ranges (e.g. "1-5,10").
+// Ranges start at or before maxStart.
+func listString(values []int, lo int, hi int, maxStart int, name func(int) string) string {
+	if len(values) == hi-lo+1 {
+		return "*"
+	}
+
+	ss := []string{}
+
+	for i := 0; i < len(values); {
+		j := i
+
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if j-i >= 2 && values[i] <= maxStart {
+			ss = append(ss, name(values[i])+"-"+name(values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				ss = append(ss, name(values[k]))
+			}
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(ss, ",")
+}
@@ -0,0 +1,119 @@
+package awsexport_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2/awsexport"
+)
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestRead(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+{
+  "Rules": [
+    {"Name": "nightly", "State": "ENABLED", "ScheduleExpression": "cron(0 3 * * ? *)", "EventBusName": "default"},
+    {"Name": "ec2", "State": "ENABLED", "EventPattern": "{\"source\":[\"aws.ec2\"]}", "EventBusName": "default"},
+    {"Name": "legacy", "State": "DISABLED", "ScheduleExpression": "rate(6 hours)"}
+  ]
+}
+{
+  "Name": "report",
+  "GroupName": "default",
+  "ScheduleExpression": "cron(0 9 ? * MON-FRI *)",
+  "ScheduleExpressionTimezone": "Asia/Tokyo",
+  "StartDate": "2024-11-01T00:00:00+09:00",
+  "EndDate": 1743346800.0,
+  "State": "ENABLED",
+  "FlexibleTimeWindow": {"Mode": "OFF"}
+}
+[
+  {"Name": "once", "ScheduleExpression": "at(2024-11-11T12:00:00)", "State": "ENABLED"}
+]
+`
+
+	schedules, err := awsexport.Read(strings.NewReader(src))
+	assert.NoError(err)
+	assert.Equal([]*awsexport.Schedule{
+		{Name: "nightly", ScheduleExpression: "cron(0 3 * * ? *)", State: awsexport.StateEnabled},
+		{Name: "legacy", ScheduleExpression: "rate(6 hours)", State: awsexport.StateDisabled},
+		{
+			Name:                       "report",
+			GroupName:                  "default",
+			ScheduleExpression:         "cron(0 9 ? * MON-FRI *)",
+			ScheduleExpressionTimezone: "Asia/Tokyo",
+			StartDate:                  ptr(time.Date(2024, 11, 1, 0, 0, 0, 0, time.FixedZone("", 9*60*60))),
+			EndDate:                    ptr(time.Date(2025, 3, 30, 15, 0, 0, 0, time.UTC)),
+			State:                      awsexport.StateEnabled,
+		},
+		{Name: "once", ScheduleExpression: "at(2024-11-11T12:00:00)", State: awsexport.StateEnabled},
+	}, schedules)
+
+	assert.True(schedules[0].Enabled())
+	assert.False(schedules[1].Enabled())
+}
+
+func TestReadErr(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		src      string
+		expected string
+	}{
+		{`{"Schedules": [{"Name": "report", "GroupName": "default", "State": "ENABLED"}]}`, "schedule 'report' has no ScheduleExpression (use the output of 'aws scheduler get-schedule')"},
+		{`{"Name": "report", "ScheduleExpression": "rate(1 hour)", "StartDate": "2024-11-01"}`, `invalid StartDate of 'report': parsing time "2024-11-01" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "" as "T"`},
+		{`{"Rules": [`, "failed to parse AWS export: unexpected EOF"},
+	}
+
+	for _, t := range tt {
+		_, err := awsexport.Read(strings.NewReader(t.src))
+		assert.EqualError(err, t.expected)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+{"Name": "report", "ScheduleExpression": "cron(0 9 ? * MON-FRI *)", "ScheduleExpressionTimezone": "Asia/Tokyo", "StartDate": "2024-11-12T00:00:00Z", "EndDate": "2024-11-14T00:00:00Z"}
+{"Name": "poll", "ScheduleExpression": "rate(90 minutes)"}
+{"Name": "once", "ScheduleExpression": "at(2024-11-11T12:00:00)", "ScheduleExpressionTimezone": "America/New_York"}
+`
+
+	schedules, err := awsexport.Read(strings.NewReader(src))
+	assert.NoError(err)
+	from := time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+
+	// 09:00 JST is 00:00 UTC, and the start and end dates are inclusive
+	times, err := schedules[0].Between(from, to)
+	assert.NoError(err)
+	assert.Equal([]time.Time{
+		time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 14, 0, 0, 0, 0, time.UTC),
+	}, times)
+
+	times, err = schedules[1].Between(from, from.Add(4*time.Hour))
+	assert.NoError(err)
+	assert.Equal([]time.Time{
+		time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 11, 1, 30, 0, 0, time.UTC),
+		time.Date(2024, 11, 11, 3, 0, 0, 0, time.UTC),
+	}, times)
+
+	times, err = schedules[2].Between(from, to)
+	assert.NoError(err)
+	assert.Equal([]time.Time{time.Date(2024, 11, 11, 17, 0, 0, 0, time.UTC)}, times)
+
+	s := &awsexport.Schedule{Name: "bad", ScheduleExpression: "cron(0 9 * * * *)"}
+	_, err = s.Between(from, to)
+	assert.EqualError(err, "either day-of-month or day-of-week must be '?'")
+}
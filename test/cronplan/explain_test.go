@@ -0,0 +1,113 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestExplain(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		tm       time.Time
+		expected []cronplan.FieldExplanation
+	}{
+		{
+			exp: "0/15 10 ? * 6L *",
+			tm:  time.Date(2023, 10, 27, 10, 30, 0, 0, time.UTC),
+			expected: []cronplan.FieldExplanation{
+				{Field: "minute", Expr: "0/15", Value: "30", Match: true, Exp: "0/15", Kind: "number step"},
+				{Field: "hour", Expr: "10", Value: "10", Match: true, Exp: "10", Kind: "number"},
+				{Field: "day-of-month", Expr: "?", Value: "27", Match: true, Exp: "?", Kind: "any"},
+				{Field: "month", Expr: "*", Value: "OCT", Match: true, Exp: "*", Kind: "wildcard"},
+				{Field: "day-of-week", Expr: "FRIL", Value: "FRI (day 27)", Match: true, Exp: "FRIL", Kind: "last day of week"},
+				{Field: "year", Expr: "*", Value: "2023", Match: true, Exp: "*", Kind: "wildcard"},
+			},
+		},
+		{
+			exp: "0/15 8-9 15W JAN,MAR ? 2024-2025",
+			tm:  time.Date(2023, 10, 15, 10, 13, 0, 0, time.UTC),
+			expected: []cronplan.FieldExplanation{
+				{Field: "minute", Expr: "0/15", Value: "13", Match: false, Nearest: "15"},
+				{Field: "hour", Expr: "8-9", Value: "10", Match: false, Nearest: "9"},
+				{Field: "day-of-month", Expr: "15W", Value: "15", Match: false, Nearest: "16"},
+				{Field: "month", Expr: "JAN,MAR", Value: "OCT", Match: false, Nearest: "MAR"},
+				{Field: "day-of-week", Expr: "?", Value: "SUN (day 15)", Match: true, Exp: "?", Kind: "any"},
+				{Field: "year", Expr: "2024-2025", Value: "2023", Match: false, Nearest: "2024"},
+			},
+		},
+		{
+			exp: "0 10 ? * MON#5 *",
+			tm:  time.Date(2023, 10, 2, 10, 0, 0, 0, time.UTC),
+			expected: []cronplan.FieldExplanation{
+				{Field: "minute", Expr: "0", Value: "0", Match: true, Exp: "0", Kind: "number"},
+				{Field: "hour", Expr: "10", Value: "10", Match: true, Exp: "10", Kind: "number"},
+				{Field: "day-of-month", Expr: "?", Value: "2", Match: true, Exp: "?", Kind: "any"},
+				{Field: "month", Expr: "*", Value: "OCT", Match: true, Exp: "*", Kind: "wildcard"},
+				{Field: "day-of-week", Expr: "MON#5", Value: "MON (day 2)", Match: false, Nearest: "MON (day 30)"},
+				{Field: "year", Expr: "*", Value: "2023", Match: true, Exp: "*", Kind: "wildcard"},
+			},
+		},
+		{
+			exp: "0 10 ? * MON#5 *",
+			tm:  time.Date(2023, 11, 27, 10, 0, 0, 0, time.UTC),
+			expected: []cronplan.FieldExplanation{
+				{Field: "minute", Expr: "0", Value: "0", Match: true, Exp: "0", Kind: "number"},
+				{Field: "hour", Expr: "10", Value: "10", Match: true, Exp: "10", Kind: "number"},
+				{Field: "day-of-month", Expr: "?", Value: "27", Match: true, Exp: "?", Kind: "any"},
+				{Field: "month", Expr: "*", Value: "NOV", Match: true, Exp: "*", Kind: "wildcard"},
+				{Field: "day-of-week", Expr: "MON#5", Value: "MON (day 27)", Match: false, Nearest: ""},
+				{Field: "year", Expr: "*", Value: "2023", Match: true, Exp: "*", Kind: "wildcard"},
+			},
+		},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		explanation := cron.Explain(t.tm)
+		assert.Equal(cron.Match(t.tm), explanation.Match, t)
+		assert.Equal(t.tm, explanation.Time)
+		actual := []cronplan.FieldExplanation{}
+
+		for _, f := range explanation.Fields {
+			actual = append(actual, *f)
+		}
+
+		assert.Equal(t.expected, actual, t)
+	}
+}
+
+func TestExplainDayOfMonthKind(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		tm       time.Time
+		expected string
+	}{
+		{"0 0 L * ? *", time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), "last day of month"},
+		{"0 0 L-2 * ? *", time.Date(2023, 2, 26, 0, 0, 0, 0, time.UTC), "last day of month with offset"},
+		{"0 0 LW * ? *", time.Date(2023, 9, 29, 0, 0, 0, 0, time.UTC), "last weekday of month"},
+		{"0 0 1W * ? *", time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), "nearest weekday"},
+		{"0 0 1,25-5 * ? *", time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), "range"},
+		{"0 0 ? * L *", time.Date(2023, 10, 7, 0, 0, 0, 0, time.UTC), "last (same as SAT)"},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		explanation := cron.Explain(t.tm)
+		assert.True(explanation.Match, t)
+
+		for _, f := range explanation.Fields {
+			if f.Field == "day-of-month" && !cron.DayOfMonth.Any || f.Field == "day-of-week" && !cron.DayOfWeek.Any {
+				assert.Equal(t.expected, f.Kind, t)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestInfer(t *testing.T) {
+	assert := assert.New(t)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+
+	tt := []struct {
+		exp      string
+		expected string
+	}{
+		{"0 9 ? * MON-FRI *", "0 9 ? * MON-FRI *"},
+		{"*/15 9-17 ? * MON-FRI *", "*/15 9-17 ? * MON-FRI *"},
+		{"5 */4 * * ? *", "5 */4 * * ? *"},
+		{"0 6-18/3 * * ? *", "0 6-18/3 * * ? *"},
+		{"0 12 1,15 * ? *", "0 12 1,15 * ? *"},
+		{"30 23 ? * FRIL *", "30 23 ? * FRIL *"},
+		{"0 10 ? * TUE#2 *", "0 10 ? * TUE#2 *"},
+		{"0 0 L * ? *", "0 0 L * ? *"},
+		{"0 0 L-2 * ? *", "0 0 L-2 * ? *"},
+		{"0 0 LW * ? *", "0 0 LW * ? *"},
+		{"0 6 15W * ? *", "0 6 15W * ? *"},
+		{"0 0 1 1/3 ? *", "0 0 1 */3 ? *"},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		inferences, err := cronplan.Infer(cron.Between(from, to))
+		assert.NoError(err)
+
+		if assert.NotEmpty(inferences, t) {
+			assert.Equal(t.expected, inferences[0].Expr.String(), t)
+			assert.Equal(1.0, inferences[0].Fit, t)
+			assert.Empty(inferences[0].Extra, t)
+		}
+	}
+}
+
+func TestInferRanking(t *testing.T) {
+	assert := assert.New(t)
+	cron, err := cronplan.Parse("0 10 ? * TUE#2 *")
+	require.NoError(t, err)
+	times := cron.Between(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	inferences, err := cronplan.Infer(times)
+	assert.NoError(err)
+
+	actual := []string{}
+
+	for _, i := range inferences {
+		actual = append(actual, i.Expr.String())
+		assert.Equal(float64(len(times))/float64(i.Runs), i.Fit, i.Expr)
+	}
+
+	assert.Equal([]string{
+		"0 10 ? * TUE#2 *",
+		"0 10 ? * TUE *",
+		"0 10 8-14 * ? *",
+		"0 10 * * ? *",
+	}, actual)
+
+	assert.Equal(49, inferences[1].Runs)
+	assert.Len(inferences[1].Extra, 49-12)
+	// the runs are counted from the first example (2024-01-09)
+	assert.Equal(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC), inferences[1].Extra[0])
+	assert.InDelta(12.0/49, inferences[1].Fit, 0.001)
+}
+
+func TestInferGaps(t *testing.T) {
+	assert := assert.New(t)
+
+	times := []time.Time{
+		time.Date(2022, 3, 1, 9, 0, 10, 0, time.UTC),
+		time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	inferences, err := cronplan.Infer(times)
+	assert.NoError(err)
+	assert.Equal("0 9 1 MAR,JUN ? 2022,2024", inferences[0].Expr.String())
+	assert.Equal(1.0, inferences[0].Fit)
+}
+
+func TestInferEmpty(t *testing.T) {
+	assert := assert.New(t)
+	_, err := cronplan.Infer(nil)
+	assert.ErrorContains(err, "no example times")
+}
@@ -0,0 +1,97 @@
+package cronplan_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/gen"
+)
+
+func TestInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp string
+		min *cronplan.Interval
+		max *cronplan.Interval
+	}{
+		{
+			exp: "*/7 * * * ? *",
+			min: &cronplan.Interval{Duration: 4 * time.Minute, From: time.Date(1970, 1, 1, 0, 56, 0, 0, time.UTC), To: time.Date(1970, 1, 1, 1, 0, 0, 0, time.UTC)},
+			max: &cronplan.Interval{Duration: 7 * time.Minute, From: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(1970, 1, 1, 0, 7, 0, 0, time.UTC)},
+		},
+		{
+			exp: "0 0 L * ? *",
+			min: &cronplan.Interval{Duration: 28 * 24 * time.Hour, From: time.Date(1970, 1, 31, 0, 0, 0, 0, time.UTC), To: time.Date(1970, 2, 28, 0, 0, 0, 0, time.UTC)},
+			max: &cronplan.Interval{Duration: 31 * 24 * time.Hour, From: time.Date(1970, 2, 28, 0, 0, 0, 0, time.UTC), To: time.Date(1970, 3, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			exp: "0 12 29 2 ? *",
+			min: &cronplan.Interval{Duration: 1461 * 24 * time.Hour, From: time.Date(1972, 2, 29, 12, 0, 0, 0, time.UTC), To: time.Date(1976, 2, 29, 12, 0, 0, 0, time.UTC)},
+			max: &cronplan.Interval{Duration: 2921 * 24 * time.Hour, From: time.Date(2096, 2, 29, 12, 0, 0, 0, time.UTC), To: time.Date(2104, 2, 29, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			exp: "0 9,18 ? * MON-FRI 2024",
+			min: &cronplan.Interval{Duration: 9 * time.Hour, From: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)},
+			max: &cronplan.Interval{Duration: 63 * time.Hour, From: time.Date(2024, 1, 5, 18, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			exp: "0 0 1 1 ? 2024,2030",
+			min: &cronplan.Interval{Duration: 2192 * 24 * time.Hour, From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+			max: &cronplan.Interval{Duration: 2192 * 24 * time.Hour, From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			exp: "0 0 1 1 ? 2024",
+		},
+		{
+			exp: "0 0 30 2 ? *",
+		},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		assert.Equal(t.min, cron.MinInterval(), t.exp)
+		assert.Equal(t.max, cron.MaxInterval(), t.exp)
+	}
+}
+
+func TestIntervalBetween(t *testing.T) {
+	assert := assert.New(t)
+	g := gen.NewGenerator(1, nil)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		exp := g.NextString()
+		exp = exp[:strings.LastIndex(exp, " ")] + " 2024-2025"
+		cron, err := cronplan.Parse(exp)
+		assert.NoError(err)
+
+		// skip the dense expressions
+		if !cron.NthAfter(from, 100000).IsZero() {
+			continue
+		}
+
+		schedule := cron.Between(from, to)
+		var min, max *cronplan.Interval
+
+		for j := 1; j < len(schedule); j++ {
+			d := schedule[j].Sub(schedule[j-1])
+
+			if min == nil || d < min.Duration {
+				min = &cronplan.Interval{Duration: d, From: schedule[j-1], To: schedule[j]}
+			}
+
+			if max == nil || d > max.Duration {
+				max = &cronplan.Interval{Duration: d, From: schedule[j-1], To: schedule[j]}
+			}
+		}
+
+		assert.Equal(min, cron.MinInterval(), exp)
+		assert.Equal(max, cron.MaxInterval(), exp)
+	}
+}
@@ -0,0 +1,57 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/gen"
+)
+
+func TestMatchAllocs(t *testing.T) {
+	assert := assert.New(t)
+
+	exps := []string{
+		"* * * * ? *",
+		"5,10-20,50-10,*/15,3/7,30-40/2,7/0,1-5/0 * * * ? *",
+		"* 1,2-4,22-2,*/3,1/2,3-9/3,4/0,5-6/0 * * ? *",
+		"* * 1,2-5,28-3,*/3,2/4,5-25/5,7/0,8-9/0 * ? *",
+		"* * L,L-3,LW,15W,1W,31W * ? *",
+		"* * * 1,FEB,MAR-MAY,NOV-FEB,*/2,2/3,JAN-DEC/4,5/0,6-7/0 ? *",
+		"* * ? * 1,MON,TUE-THU,FRI-MON,*/2,2/3,SUN-SAT/2,4/0,5-6/0 *",
+		"* * ? * L,5L,MON#1,FRI#5 *",
+		"* * * * ? 2024,2025-2030,2199-1970,*/2,1971/3,2000-2100/7,2026/0,2027-2028/0",
+	}
+
+	g := gen.NewGenerator(1, nil)
+
+	for i := 0; i < 200; i++ {
+		exps = append(exps, g.NextString())
+	}
+
+	tm := time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC)
+
+	for _, exp := range exps {
+		cron, err := cronplan.Parse(exp)
+		assert.NoError(err)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			for i := 0; i < 10; i++ {
+				cron.Match(tm.AddDate(0, 0, i))
+			}
+		})
+
+		assert.Zero(allocs, exp)
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	cron, _ := cronplan.Parse("0/5 8-17 ? JAN-MAR,DEC MON-FRI,SAT#1,L 2024-2026")
+	tm := time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cron.Match(tm)
+	}
+}
@@ -0,0 +1,38 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestMatchWithin(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp       string
+		tm        time.Time
+		d         time.Duration
+		scheduled time.Time
+		expected  bool
+	}{
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 10, 0, 0, time.UTC), 0, time.Date(2023, 10, 6, 12, 10, 0, 0, time.UTC), true},
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 10, 30, 0, time.UTC), 0, time.Time{}, false},
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 10, 30, 0, time.UTC), time.Minute, time.Date(2023, 10, 6, 12, 10, 0, 0, time.UTC), true},
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 13, 0, 0, time.UTC), 3 * time.Minute, time.Date(2023, 10, 6, 12, 10, 0, 0, time.UTC), true},
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 13, 1, 0, time.UTC), 3 * time.Minute, time.Time{}, false},
+		{"10 12 * * ? *", time.Date(2023, 10, 6, 12, 9, 59, 0, time.UTC), 3 * time.Minute, time.Time{}, false},
+		{"59 23 L * ? *", time.Date(2023, 11, 1, 0, 1, 0, 0, time.UTC), 5 * time.Minute, time.Date(2023, 10, 31, 23, 59, 0, 0, time.UTC), true},
+		{"0 0 1 1 ? 2030", time.Date(2023, 11, 1, 0, 1, 0, 0, time.UTC), time.Hour, time.Time{}, false},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		scheduled, ok := cron.MatchWithin(t.tm, t.d)
+		assert.Equal(t.expected, ok, t)
+		assert.Equal(t.scheduled, scheduled, t)
+	}
+}
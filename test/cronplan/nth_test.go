@@ -0,0 +1,109 @@
+package cronplan_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/gen"
+)
+
+func TestNthAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		from     time.Time
+		k        int
+		expected time.Time
+	}{
+		{"*/7 * * * ? *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"*/7 * * * ? *", time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC), 1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"*/7 * * * ? *", time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC), 1, time.Date(2024, 1, 1, 0, 7, 0, 0, time.UTC)},
+		{"*/7 * * * ? *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 10000, time.Date(2024, 2, 16, 7, 0, 0, 0, time.UTC)},
+		{"0 0 L * ? *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 2, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 L * ? *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 14, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"0 9 ? * MON#1 2030", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 12, time.Date(2030, 12, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 9 ? * MON#1 2030", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 13, time.Time{}},
+		{"0 9 ? * MON#1 *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1 << 62, time.Time{}},
+		{"0 9 ? * MON#1 *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0, time.Time{}},
+		{"0 9 31 2 ? *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1, time.Time{}},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		assert.Equal(t.expected, cron.NthAfter(t.from, t.k), t)
+
+		if !t.expected.IsZero() {
+			i, ok := cron.IndexOf(t.from, t.expected)
+			assert.True(ok, t)
+			assert.Equal(t.k, i, t)
+		}
+	}
+}
+
+func TestNthAfterNextN(t *testing.T) {
+	assert := assert.New(t)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(err)
+	r := rand.New(rand.NewSource(1))
+	g := gen.NewGenerator(1, nil)
+
+	for i := 0; i < 100; i++ {
+		cron := g.Next()
+		loc := []*time.Location{time.UTC, newYork}[i%2]
+		from := time.Date(2020+r.Intn(10), 1, 1, 0, 0, 0, 0, loc).Add(time.Duration(r.Int63n(int64(365 * 24 * time.Hour))))
+		schedule := cron.NextN(from, 10)
+
+		for k, next := range schedule {
+			assert.Equal(next, cron.NthAfter(from, k+1), "%s from %s", cron, from)
+
+			// a nonexistent time in a DST gap may be shifted onto another occurrence
+			i, ok := cron.IndexOf(from, next)
+			assert.True(ok, "%s from %s", cron, from)
+			assert.Equal(next, cron.NthAfter(from, i), "%s from %s", cron, from)
+		}
+
+		assert.True(cron.NthAfter(from, len(schedule)+1).IsZero() == (len(schedule) < 10), "%s from %s", cron, from)
+	}
+}
+
+func TestIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	cron, err := cronplan.Parse("0 9 ? * MON-FRI *")
+	assert.NoError(err)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		tm       time.Time
+		expected int
+		ok       bool
+	}{
+		{time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), 1, true},
+		{time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), 6, true},
+		{time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), 263, true},
+		{time.Date(2024, 1, 8, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60)), 6, true},
+		{time.Date(2024, 1, 6, 9, 0, 0, 0, time.UTC), 0, false},
+		{time.Date(2024, 1, 8, 9, 0, 1, 0, time.UTC), 0, false},
+		{time.Date(2023, 12, 29, 9, 0, 0, 0, time.UTC), 0, false},
+		{time.Date(2200, 1, 1, 9, 0, 0, 0, time.UTC), 0, false},
+	}
+
+	for _, t := range tt {
+		i, ok := cron.IndexOf(from, t.tm)
+		assert.Equal(t.ok, ok, t.tm)
+		assert.Equal(t.expected, i, t.tm)
+	}
+}
+
+func BenchmarkNthAfter(b *testing.B) {
+	cron, _ := cronplan.Parse("*/7 9-17 ? * MON-FRI *")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < b.N; i++ {
+		cron.NthAfter(from, 1000000)
+	}
+}
@@ -0,0 +1,214 @@
+package cronplan_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/gen"
+)
+
+func parseWithParticiple(exp string) (*cronplan.Expression, error) {
+	cron, err := cronplan.Parser.ParseString("", strings.TrimSpace(exp))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cron.DayOfMonth.Any == cron.DayOfWeek.Any {
+		return nil, fmt.Errorf("invalid day-of-month and day-of-week")
+	}
+
+	return cron, nil
+}
+
+func assertSameParse(t *testing.T, exp string) bool {
+	expected, expectedErr := parseWithParticiple(exp)
+	actual, actualErr := cronplan.Parse(exp)
+
+	if expectedErr != nil {
+		return assert.Error(t, actualErr, "%q is accepted, but Parser rejects it: %s", exp, expectedErr)
+	}
+
+	return assert.NoError(t, actualErr, "%q is rejected, but Parser accepts it", exp) &&
+		assert.Equal(t, expected, actual, exp)
+}
+
+func TestParseDifferential(t *testing.T) {
+	exps := []string{
+		"",
+		" ",
+		"* * * * ? *",
+		"  *  *\t* * ?  * \n",
+		"* * * * ?",
+		"* * * * ? * *",
+		"*/010 * * * ? *",
+		"*/08 * * * ? *",
+		"*/0x10 * * * ? *",
+		"*/ * * * ? *",
+		"60 * * * ? *",
+		"99999999999999999999 * * * ? *",
+		"1-60 * * * ? *",
+		"1- * * * ? *",
+		"-1 * * * ? *",
+		"*-1 * * * ? *",
+		"1,,2 * * * ? *",
+		"1, * * * ? *",
+		",1 * * * ? *",
+		"x * * * ? *",
+		"* 24 * * ? *",
+		"* * 0 * ? *",
+		"* * 32W * ? *",
+		"* * 0W * ? *",
+		"* * W * ? *",
+		"* * L-31 * ? *",
+		"* * L-0 * ? *",
+		"* * L- * ? *",
+		"* * L/2 * ? *",
+		"* * 5W/2 * ? *",
+		"* * LW-2 * ? *",
+		"* * LW/2 * ? *",
+		"* * l * ? *",
+		"* * 1-31/0 * ? *",
+		"* * ?,1 * * *",
+		"* * 1,? * ? *",
+		"* * ? * ? *",
+		"* * * * * *",
+		"* * * jan ? *",
+		"* * * Jan-dEC/2 ? *",
+		"* * * JANL ? *",
+		"* * * JUNE ? *",
+		"* * * 1-JAN ? *",
+		"* * * 0 ? *",
+		"* * * 13 ? *",
+		"* * * MON ? *",
+		"* * ? * 0 *",
+		"* * ? * 8L *",
+		"* * ? * L *",
+		"* * ? * L/2 *",
+		"* * ? * L-2 *",
+		"* * ? * MONL/2 *",
+		"* * ? * wedl *",
+		"* * ? * WEDL *",
+		"* * ? * 1#0 *",
+		"* * ? * 1#99 *",
+		"* * ? * 1#010 *",
+		"* * ? * 1#08 *",
+		"* * ? * 1#-1 *",
+		"* * ? * 1# *",
+		"* * ? * #1 *",
+		"* * ? * SUN#1/2 *",
+		"* * ? * 1-SUN *",
+		"* * ? * SAT-SUN/2 *",
+		"* * ? * monday *",
+		"* * ? * JAN *",
+		"* * ? * */99999999999999999999 *",
+		"* * ? * 1#99999999999999999999 *",
+		"* * * * ? 1969",
+		"* * * * ? 2200",
+		"* * * * ? 1970-2199/229",
+		"* * * * ? */1_0",
+		"* * * * ? *x",
+		"* * * * ? *,",
+		"* * * * ? *",
+		" * * * * ? * ",
+	}
+
+	for _, exp := range exps {
+		assertSameParse(t, exp)
+	}
+}
+
+func TestParseDifferentialGenerated(t *testing.T) {
+	chars := []rune(" \t,-*?/LW#0123456789JANMONWEDlw x")
+	r := rand.New(rand.NewSource(1))
+	g := gen.NewGenerator(1, nil)
+
+	for i := 0; i < 5000; i++ {
+		exp := []rune(g.NextString())
+
+		if !assertSameParse(t, string(exp)) {
+			continue
+		}
+
+		for j := 0; j < 5; j++ {
+			mutated := append([]rune{}, exp...)
+			k := r.Intn(len(mutated))
+
+			switch r.Intn(3) {
+			case 0:
+				mutated = append(mutated[:k], mutated[k+1:]...)
+			case 1:
+				mutated = append(mutated[:k], append([]rune{chars[r.Intn(len(chars))]}, mutated[k:]...)...)
+			default:
+				mutated[k] = chars[r.Intn(len(chars))]
+			}
+
+			assertSameParse(t, string(mutated))
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		expected string
+	}{
+		{"", "1:1: unexpected end of expression in minute"},
+		{"* * * * ?", "1:10: missing year"},
+		{"* * * * ? * *", "1:13: unexpected '*' after year"},
+		{"60 * * * ? *", "1:1: minute must be 0-59 (value=60)"},
+		{"  1-60 * * * ? *", "1:5: minute must be 0-59 (value=60)"},
+		{"1,,2 * * * ? *", "1:3: unexpected ',' in minute"},
+		{"* * L/2 * ? *", "1:6: unexpected '/' before month"},
+		{"* * L-31 * ? *", "1:7: 'L-<num>' must be 1-30 (value=31)"},
+		{"* * 32W * ? *", "1:5: '<num>W' must be 1-31 (value=32)"},
+		{"* * * JUNE ? *", "1:10: unexpected 'E' before day-of-week"},
+		{"* * * MON ? *", "1:7: unexpected 'MON' in month"},
+		{"* * ? * 8L *", "1:9: day-of-week number must be 1-7 (value=8)"},
+		{"* * ? * 1#08 *", "1:11: invalid number '08' in day-of-week"},
+		{"* * ? * SUN# *", "1:13: unexpected space in day-of-week"},
+		{"* * * * ? */", "1:13: unexpected end of expression in year"},
+		{"* * * * ? 1969", "1:11: year must be 1970-2199 (value=1969)"},
+		{"* * * * ? é", "1:11: unexpected 'é' in year"},
+		{"\n* * * * ? *x", "2:12: unexpected 'x' after year"},
+		{"* * ? * ? *", "'?' cannot be set to both day-of-month and day-of-week"},
+		{"* * * * * *", "either day-of-month or day-of-week must be '?'"},
+	}
+
+	for _, t := range tt {
+		_, err := cronplan.Parse(t.exp)
+		assert.EqualError(err, t.expected, t.exp)
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	exp := "0/5 8-17 ? JAN-MAR,DEC MON-FRI,SAT#1,L 2024-2026"
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = cronplan.Parse(exp)
+	})
+
+	participleAllocs := testing.AllocsPerRun(100, func() {
+		_, _ = parseWithParticiple(exp)
+	})
+
+	assert.Less(t, allocs*5, participleAllocs)
+}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = cronplan.Parse("0/5 8-17 ? JAN-MAR,DEC MON-FRI,SAT#1,L 2024-2026")
+	}
+}
+
+func BenchmarkParserParseString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = cronplan.Parser.ParseString("", "0/5 8-17 ? JAN-MAR,DEC MON-FRI,SAT#1,L 2024-2026")
+	}
+}
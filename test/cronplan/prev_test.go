@@ -0,0 +1,106 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestPrev(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			exp:      "0 10 * * ? *",
+			from:     time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "0 10 * * ? *",
+			from:     time.Date(2022, 11, 3, 9, 59, 0, 0, time.UTC),
+			expected: time.Date(2022, 11, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "0 10 * * ? *",
+			from:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 12, 31, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "*/15 * * * ? *",
+			from:     time.Date(2022, 11, 3, 10, 14, 59, 0, time.UTC),
+			expected: time.Date(2022, 11, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "0 0 L * ? *",
+			from:     time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "0 0 ? * 6L *",
+			from:     time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 10, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			exp:      "35 9 17 DEC ? 2023",
+			from:     time.Date(2023, 12, 17, 9, 34, 0, 0, time.UTC),
+			expected: time.Time{},
+		},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		assert.Equal(t.expected, cron.Prev(t.from), t)
+	}
+}
+
+func TestPrevN(t *testing.T) {
+	assert := assert.New(t)
+	cron, err := cronplan.Parse("30 9,17 ? * MON-FRI *")
+	assert.NoError(err)
+	jst := time.FixedZone("JST", 9*60*60)
+
+	assert.Equal([]time.Time{
+		time.Date(2022, 11, 7, 9, 30, 0, 0, jst),
+		time.Date(2022, 11, 4, 17, 30, 0, 0, jst),
+		time.Date(2022, 11, 4, 9, 30, 0, 0, jst),
+	}, cron.PrevN(time.Date(2022, 11, 7, 12, 0, 0, 0, jst), 3))
+
+	assert.Equal([]time.Time{}, cron.PrevN(time.Date(2022, 11, 7, 12, 0, 0, 0, jst), 0))
+}
+
+func TestPrevEqBetween(t *testing.T) {
+	assert := assert.New(t)
+
+	exps := []string{
+		"*/7 * * * ? *",
+		"5-10,50-55 22-2 * * ? *",
+		"0 */3 15W * ? *",
+		"30 9 ? * 2#1 *",
+		"15 12 L-2 * ? *",
+		"0/20 6-18/4 ? JAN-MAR,NOV-DEC SAT,SUN *",
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, exp := range exps {
+		cron, err := cronplan.Parse(exp)
+		assert.NoError(err)
+		schedule := cron.Between(from, to)
+		prev := cron.PrevN(to, len(schedule))
+		n := len(prev)
+
+		for i := 0; i < n/2; i++ {
+			prev[i], prev[n-1-i] = prev[n-1-i], prev[i]
+		}
+
+		assert.Equal(schedule, prev, exp)
+	}
+}
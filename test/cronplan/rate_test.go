@@ -0,0 +1,158 @@
+package cronplan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestParseRate(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		expected *cronplan.Rate
+	}{
+		{"rate(1 minute)", &cronplan.Rate{Value: 1, Unit: cronplan.RateMinute}},
+		{"rate(10 minutes)", &cronplan.Rate{Value: 10, Unit: cronplan.RateMinute}},
+		{" rate( 2 hours ) ", &cronplan.Rate{Value: 2, Unit: cronplan.RateHour}},
+		{"rate(1 day)", &cronplan.Rate{Value: 1, Unit: cronplan.RateDay}},
+	}
+
+	for _, t := range tt {
+		rate, err := cronplan.ParseRate(t.exp)
+		assert.NoError(err)
+		assert.Equal(t.expected, rate)
+	}
+}
+
+func TestParseRateErr(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		expected string
+	}{
+		{"rate(10 seconds)", "invalid rate expression 'rate(10 seconds)'"},
+		{"0/10 * * * ? *", "invalid rate expression '0/10 * * * ? *'"},
+		{"rate(0 minutes)", "rate value must be a positive integer (value=0)"},
+		{"rate(1 minutes)", "rate unit must be 'minute' for 1"},
+		{"rate(2 hour)", "rate unit must be 'hours' for 2"},
+	}
+
+	for _, t := range tt {
+		_, err := cronplan.ParseRate(t.exp)
+		assert.EqualError(err, t.expected)
+	}
+}
+
+func TestRateToCron(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		rate     string
+		expected []string
+		note     string
+	}{
+		{"rate(1 minute)", []string{"* * * * ? *"}, ""},
+		{"rate(10 minutes)", []string{"*/10 * * * ? *"}, ""},
+		{"rate(90 minutes)", []string{"0 */3 * * ? *", "30 1/3 * * ? *"}, ""},
+		{"rate(2 hours)", []string{"0 */2 * * ? *"}, ""},
+		{"rate(5 hours)", []string{"0 */5 * * ? *"}, "rate(5 hours) does not divide a day evenly: the expressions restart at 00:00 every day, so the gap before 00:00 is 4h0m0s"},
+		{"rate(1 day)", []string{"0 0 * * ? *"}, ""},
+		{"rate(7 days)", []string{"0 0 ? * SUN *"}, ""},
+		{"rate(3 days)", []string{"0 0 1/3 * ? *"}, "rate(3 days) does not divide a month evenly: the expression restarts on the 1st of every month"},
+		{"rate(15 days)", []string{"0 0 1/15 * ? *"}, "rate(15 days) does not divide a month evenly: the expression restarts on the 1st of every month"},
+	}
+
+	for _, t := range tt {
+		rate, err := cronplan.ParseRate(t.rate)
+		assert.NoError(err)
+		exprs, note, err := rate.ToCron()
+		assert.NoError(err)
+		actual := []string{}
+
+		for _, e := range exprs {
+			actual = append(actual, e.String())
+		}
+
+		assert.Equal(t.expected, actual, t.rate)
+		assert.Equal(t.note, note, t.rate)
+	}
+
+	rate, _ := cronplan.ParseRate("rate(25 hours)")
+	_, _, err := rate.ToCron()
+	assert.EqualError(err, "rate(25 hours) cannot be converted to cron expressions")
+
+	for _, r := range []string{"rate(16 days)", "rate(45 days)"} {
+		rate, _ := cronplan.ParseRate(r)
+		_, _, err := rate.ToCron()
+		assert.EqualError(err, r+" cannot be converted to cron expressions: the expression would restart on the 1st of every month before the second run")
+	}
+}
+
+func TestRateToCronMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, step := range []int{1, 7, 13, 45, 90, 100, 360, 1439} {
+		rate := &cronplan.Rate{Value: step, Unit: cronplan.RateMinute}
+		exprs, _, err := rate.ToCron()
+		assert.NoError(err)
+		day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		for tod := 0; tod < 24*60; tod++ {
+			tm := day.Add(time.Duration(tod) * time.Minute)
+			matched := false
+
+			for _, e := range exprs {
+				matched = matched || e.Match(tm)
+			}
+
+			assert.Equal(tod%step == 0, matched, "%s at %s", rate, tm)
+		}
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		period   time.Duration
+		periodic bool
+		rate     string
+	}{
+		{"0/10 * * * ? *", 10 * time.Minute, true, "rate(10 minutes)"},
+		{"* * * * ? *", time.Minute, true, "rate(1 minute)"},
+		{"30 */2 * * ? *", 2 * time.Hour, true, "rate(2 hours)"},
+		{"0 0 * * ? *", 24 * time.Hour, true, "rate(1 day)"},
+		{"0 0 ? * MON *", 7 * 24 * time.Hour, true, "rate(7 days)"},
+		{"0 0,12 * * ? *", 12 * time.Hour, true, "rate(12 hours)"},
+		{"*/7 * * * ? *", 0, false, ""},
+		{"0 0 1 * ? *", 0, false, ""},
+		{"0,30 0/3 * * ? *", 0, false, ""},
+		{"0 0 1 JAN ? 2024", 0, false, ""},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		period, ok := cron.Period()
+		assert.Equal(t.period, period, t.exp)
+		assert.Equal(t.periodic, ok, t.exp)
+		rate, err := cron.ToRate()
+
+		if t.periodic {
+			assert.NoError(err)
+			assert.Equal(t.rate, rate.String())
+		} else {
+			assert.EqualError(err, "'"+t.exp+"' is not periodic")
+		}
+	}
+
+	cron, _ := cronplan.Parse("*/10 * * * ? 2024")
+	_, err := cron.ToRate()
+	assert.EqualError(err, "'*/10 * * * ? 2024' is limited to some years")
+}
@@ -0,0 +1,218 @@
+package cronplan_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func newExpressionSet(t testing.TB, exprs map[string]string) *cronplan.ExpressionSet {
+	set := cronplan.NewExpressionSet()
+
+	for name, exp := range exprs {
+		cron, err := cronplan.Parse(exp)
+		require.NoError(t, err)
+		set.Add(name, cron)
+	}
+
+	return set
+}
+
+func TestExpressionSetAt(t *testing.T) {
+	assert := assert.New(t)
+
+	set := newExpressionSet(t, map[string]string{
+		"hourly":   "0 * * * ? *",
+		"daily":    "0 10 * * ? *",
+		"weekdays": "0 10 ? * MON-FRI *",
+		"last_fri": "0 10 ? * 6L *",
+		"lw":       "0 10 LW * ? *",
+		"y2023":    "0 10 * * ? 2023",
+	})
+
+	assert.Equal(6, set.Len())
+
+	tt := []struct {
+		tm       time.Time
+		expected []string
+	}{
+		{time.Date(2022, 10, 28, 10, 0, 0, 0, time.UTC), []string{"daily", "hourly", "last_fri", "weekdays"}},
+		{time.Date(2022, 10, 29, 10, 0, 0, 0, time.UTC), []string{"daily", "hourly"}},
+		{time.Date(2022, 10, 31, 10, 0, 0, 0, time.UTC), []string{"daily", "hourly", "lw", "weekdays"}},
+		{time.Date(2022, 10, 31, 11, 0, 0, 0, time.UTC), []string{"hourly"}},
+		{time.Date(2022, 10, 31, 11, 1, 0, 0, time.UTC), []string{}},
+		{time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), []string{"daily", "hourly", "y2023"}},
+		{time.Date(2200, 1, 1, 10, 0, 0, 0, time.UTC), []string{}},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, set.At(t.tm), t)
+	}
+}
+
+func TestExpressionSetAtEqMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	exprs := map[string]string{
+		"a": "*/7 * * * ? *",
+		"b": "5-10,50-55 22-2 * * ? *",
+		"c": "0 */3 15W * ? *",
+		"d": "30 9 ? * 2#1 *",
+		"e": "15 12 L-2 * ? *",
+		"f": "0/20 6-18/4 ? JAN-MAR,NOV-DEC SAT,SUN 2024-2025",
+		"g": "59 23 31 * ? *",
+	}
+
+	set := newExpressionSet(t, exprs)
+	crons := map[string]*cronplan.Expression{}
+
+	for name := range exprs {
+		crons[name], _ = set.Get(name)
+	}
+
+	for tm := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC); tm.Before(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)); tm = tm.Add(time.Minute) {
+		names := set.At(tm)
+		expected := []string{}
+
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+			if crons[name].Match(tm) {
+				expected = append(expected, name)
+			}
+		}
+
+		assert.Equal(expected, names, tm)
+	}
+}
+
+func TestExpressionSetAddRemove(t *testing.T) {
+	assert := assert.New(t)
+	tm := time.Date(2022, 10, 31, 10, 0, 0, 0, time.UTC)
+
+	set := newExpressionSet(t, map[string]string{
+		"a": "0 10 * * ? *",
+		"b": "0 11 * * ? *",
+	})
+
+	assert.Equal([]string{"a"}, set.At(tm))
+
+	cron, err := cronplan.Parse("0 10 * * ? *")
+	assert.NoError(err)
+	set.Add("b", cron)
+	assert.Equal(2, set.Len())
+	assert.Equal([]string{"a", "b"}, set.At(tm))
+
+	assert.True(set.Remove("a"))
+	assert.False(set.Remove("a"))
+	assert.Equal(1, set.Len())
+	assert.Equal([]string{"b"}, set.At(tm))
+
+	_, ok := set.Get("a")
+	assert.False(ok)
+
+	set.Add("c", cron)
+	assert.Equal([]string{"b", "c"}, set.At(tm))
+}
+
+func TestExpressionSetBetween(t *testing.T) {
+	assert := assert.New(t)
+
+	set := newExpressionSet(t, map[string]string{
+		"a": "0 * * * ? *",
+		"b": "30 1 * * ? *",
+		"c": "0 2 ? * MON *",
+	})
+
+	schedule := set.Between(
+		time.Date(2022, 10, 10, 0, 30, 0, 0, time.UTC),
+		time.Date(2022, 10, 10, 2, 0, 0, 0, time.UTC),
+	)
+
+	assert.Equal([]cronplan.SetOccurrence{
+		{Time: time.Date(2022, 10, 10, 1, 0, 0, 0, time.UTC), Names: []string{"a"}},
+		{Time: time.Date(2022, 10, 10, 1, 30, 0, 0, time.UTC), Names: []string{"b"}},
+		{Time: time.Date(2022, 10, 10, 2, 0, 0, 0, time.UTC), Names: []string{"a", "c"}},
+	}, schedule)
+}
+
+func TestExpressionSetBetween_From_eq_To(t *testing.T) {
+	assert := assert.New(t)
+	set := newExpressionSet(t, map[string]string{"a": "* * * * ? *"})
+	from := time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal([]cronplan.SetOccurrence{}, set.Between(from, to))
+}
+
+func newBenchmarkExpressions(n int) []*cronplan.Expression {
+	crons := make([]*cronplan.Expression, 0, n)
+	dows := []string{"?", "MON-FRI", "SAT,SUN", "6L", "2#1"}
+
+	for i := 0; i < n; i++ {
+		var exp string
+		dow := dows[i%len(dows)]
+
+		if dow == "?" {
+			exp = fmt.Sprintf("%d %d-%d * * ? *", i%60, i%24, (i+3)%24)
+		} else {
+			exp = fmt.Sprintf("%d %d ? * %s *", i%60, (i/60)%24, dow)
+		}
+
+		cron, err := cronplan.Parse(exp)
+
+		if err != nil {
+			panic(err)
+		}
+
+		crons = append(crons, cron)
+	}
+
+	return crons
+}
+
+func BenchmarkExpressionSetAt(b *testing.B) {
+	set := cronplan.NewExpressionSet()
+
+	for i, cron := range newBenchmarkExpressions(10000) {
+		set.Add(fmt.Sprintf("job%d", i), cron)
+	}
+
+	tm := time.Date(2022, 10, 31, 10, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		set.At(tm.Add(time.Duration(i%1440) * time.Minute))
+	}
+}
+
+func BenchmarkExpressionSetBetween(b *testing.B) {
+	set := cronplan.NewExpressionSet()
+
+	for i, cron := range newBenchmarkExpressions(10000) {
+		set.Add(fmt.Sprintf("job%d", i), cron)
+	}
+
+	from := time.Date(2022, 10, 31, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		set.Between(from, to)
+	}
+}
+
+func BenchmarkMatchEach(b *testing.B) {
+	crons := newBenchmarkExpressions(10000)
+	tm := time.Date(2022, 10, 31, 10, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		t := tm.Add(time.Duration(i%1440) * time.Minute)
+
+		for _, cron := range crons {
+			cron.Match(t)
+		}
+	}
+}
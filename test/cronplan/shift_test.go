@@ -0,0 +1,134 @@
+package cronplan_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestShiftZone(t *testing.T) {
+	assert := assert.New(t)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		exp      string
+		from     *time.Location
+		to       *time.Location
+		expected []string
+	}{
+		{"0 9 ? * MON-FRI *", tokyo, time.UTC, []string{"0 0 ? * MON-FRI *"}},
+		{"0 8 ? * MON-FRI *", tokyo, time.UTC, []string{"0 23 ? * SUN-THU *"}},
+		{"30 8,9 ? * MON *", tokyo, time.UTC, []string{"30 23 ? * SUN *", "30 0 ? * MON *"}},
+		{"0 */2 * * ? *", tokyo, kolkata, []string{"30 */2 * * ? *"}},
+		{"*/15 * * * ? *", tokyo, kolkata, []string{"*/15 * * * ? *"}},
+		{"0 8 * * ? *", tokyo, time.UTC, []string{"0 23 * * ? *"}},
+		{"0 8 1 * ? *", tokyo, time.UTC, []string{"0 23 L * ? *"}},
+		{"0 8 */2 * ? *", tokyo, time.UTC, []string{"0 23 L,2/2 * ? *"}},
+		{"0 8 1 JAN ? *", tokyo, time.UTC, []string{"0 23 31 DEC ? *"}},
+		{"0 8 1 JAN ? 2024", tokyo, time.UTC, []string{"0 23 31 DEC ? 2023"}},
+		{"0 8 * JAN ? *", tokyo, time.UTC, []string{"0 23 31 DEC ? *", "0 23 1-30 JAN ? *"}},
+		{"0 20 L * ? *", time.UTC, tokyo, []string{"0 5 1 * ? *"}},
+		{"0 20 ? * FRI *", time.UTC, tokyo, []string{"0 5 ? * SAT *"}},
+		// the days carried to another month are limited to the months that have the source day
+		{"0 20 31 12 ? 2025", time.UTC, tokyo, []string{"0 5 1 JAN ? 2026"}},
+		{"0 20 30 * ? *", time.UTC, tokyo, []string{"0 5 31 JAN,MAR,MAY,JUL,AUG,OCT,DEC ? *", "0 5 1 MAY,JUL,OCT,DEC ? *"}},
+		{"0 1 31 * ? *", tokyo, time.UTC, []string{"0 16 30 JAN,MAR,MAY,JUL,AUG,OCT,DEC ? *"}},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		exprs, err := cronplan.ShiftZone(cron, t.from, t.to, now)
+		assert.NoError(err, t)
+		actual := []string{}
+
+		for _, e := range exprs {
+			actual = append(actual, e.String())
+		}
+
+		assert.Equal(t.expected, actual, t)
+	}
+}
+
+func TestShiftZoneNoExactForm(t *testing.T) {
+	assert := assert.New(t)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, exp := range []string{"0 8 LW * ? *", "0 8 15W * ? *", "0 8 ? * MON#1 *", "0 8 ? * FRIL *"} {
+		cron, err := cronplan.Parse(exp)
+		require.NoError(t, err)
+		exprs, err := cronplan.ShiftZone(cron, tokyo, time.UTC, now)
+		assert.Nil(exprs)
+
+		var shiftErr *cronplan.ShiftError
+
+		if assert.True(errors.As(err, &shiftErr), exp) {
+			assert.Equal(tokyo, shiftErr.From)
+			assert.Equal(time.UTC, shiftErr.To)
+			assert.NotEmpty(shiftErr.Times)
+
+			for _, tm := range shiftErr.Times {
+				assert.True(cron.Match(tm), tm)
+				assert.False(tm.Before(now), tm)
+			}
+		}
+	}
+}
+
+func TestShiftZoneNoExactForm_MonthCarry(t *testing.T) {
+	assert := assert.New(t)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	cron, err := cronplan.Parse("0 8 ? JAN MON *")
+	require.NoError(t, err)
+	_, err = cronplan.ShiftZone(cron, tokyo, time.UTC, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var shiftErr *cronplan.ShiftError
+
+	if assert.True(errors.As(err, &shiftErr)) {
+		// SUN on 2028-12-31 is not in JAN, and SUN on 2027-01-31 fires on MON in FEB
+		assert.Contains(shiftErr.Times, time.Date(2029, 1, 1, 8, 0, 0, 0, tokyo))
+		assert.Contains(shiftErr.Extra, time.Date(2027, 2, 1, 8, 0, 0, 0, tokyo))
+
+		for _, tm := range shiftErr.Times {
+			assert.True(cron.Match(tm), tm)
+		}
+
+		for _, tm := range shiftErr.Extra {
+			assert.False(cron.Match(tm), tm)
+		}
+	}
+
+	assert.ErrorContains(err, "affected occurrences: 2029-01-01T08:00:00+09:00, ")
+	assert.ErrorContains(err, "; extra occurrences: 2027-02-01T08:00:00+09:00, ")
+}
+
+func TestShiftZoneDST(t *testing.T) {
+	assert := assert.New(t)
+	newYork, _ := time.LoadLocation("America/New_York")
+	cron, err := cronplan.Parse("0 9 ? * MON-FRI *")
+	require.NoError(t, err)
+
+	exprs, err := cronplan.ShiftZone(cron, newYork, time.UTC, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(exprs)
+
+	var shiftErr *cronplan.ShiftError
+
+	if assert.True(errors.As(err, &shiftErr)) {
+		assert.NotEmpty(shiftErr.Times)
+
+		// EST is shorter than EDT
+		for _, tm := range shiftErr.Times {
+			name, _ := tm.Zone()
+			assert.Equal("EST", name, tm)
+		}
+	}
+
+	assert.ErrorContains(err, "'0 9 ? * MON-FRI *' has no exact form in UTC")
+}
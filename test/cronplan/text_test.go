@@ -0,0 +1,136 @@
+package cronplan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+)
+
+func TestFromText(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		text     string
+		expected []string
+	}{
+		{"every weekday at 9:30 and 15:30", []string{"30 9,15 ? * MON-FRI *"}},
+		{"first Monday of each month at midnight", []string{"0 0 ? * MON#1 *"}},
+		{"on the 3rd friday of the month at 9am", []string{"0 9 ? * FRI#3 *"}},
+		{"every 15 minutes during business hours", []string{"*/15 9-17 ? * MON-FRI *"}},
+		{"every 15 minutes during business hours on weekends", []string{"*/15 9-17 ? * SAT,SUN *"}},
+		{"every day at 9:00 and 15:30", []string{"0 9 * * ? *", "30 15 * * ? *"}},
+		{"at 9:00, 9:30, 15:00 and 15:30", []string{"0,30 9,15 * * ? *"}},
+		{"every minute", []string{"* * * * ? *"}},
+		{"every 6 hours", []string{"0 */6 * * ? *"}},
+		{"hourly", []string{"0 * * * ? *"}},
+		{"every hour from 9 to 17 at minute 30", []string{"30 9-17 * * ? *"}},
+		{"every 2 hours between 8am and 8pm", []string{"0 8-20/2 * * ? *"}},
+		{"from monday to friday every 30 minutes between 9am and 5pm", []string{"*/30 9-17 ? * MON-FRI *"}},
+		{"every monday, wednesday and friday at 8:15", []string{"15 8 ? * MON,WED,FRI *"}},
+		{"on Sundays at noon", []string{"0 12 ? * SUN *"}},
+		{"on the last friday of the month at 10pm", []string{"0 22 ? * FRIL *"}},
+		{"on the 1st and 15th of each month at noon", []string{"0 12 1,15 * ? *"}},
+		{"on the last day of the month at 11:59pm", []string{"59 23 L * ? *"}},
+		{"3 days before the last day of the month", []string{"0 0 L-3 * ? *"}},
+		{"on the last weekday of the month at 9 in 2025", []string{"0 9 LW * ? 2025"}},
+		{"on the weekday nearest the 15th at 6:00", []string{"0 6 15W * ? *"}},
+		{"in january and july on the 1st at 9am", []string{"0 9 1 JAN,JUL ? *"}},
+		{"every day from march to may at 7:00", []string{"0 7 * MAR-MAY ? *"}},
+		{"monthly", []string{"0 0 1 * ? *"}},
+		{"yearly", []string{"0 0 1 JAN ? *"}},
+		{"on the 1st of april at noon", []string{"0 12 1 APR ? *"}},
+		{"in february on the 29th", []string{"0 0 29 FEB ? *"}},
+		{"every monday at 9; every friday at 17:00", []string{"0 9 ? * MON *", "0 17 ? * FRI *"}},
+		{"minute(5-10,50-55) hour(22-2) day-of-week(2#1)", []string{"5-10,50-55 22-2 ? * MON#1 *"}},
+	}
+
+	for _, t := range tt {
+		exprs, err := cronplan.FromText(t.text)
+		assert.NoError(err, t.text)
+		actual := []string{}
+
+		for _, e := range exprs {
+			actual = append(actual, e.String())
+		}
+
+		assert.Equal(t.expected, actual, t.text)
+	}
+}
+
+func TestFromTextErr(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		text     string
+		expected string
+	}{
+		{"every 7 minutes", "ambiguous phrase: every 7 minutes restarts at every hour (use a divisor of 60) (in 'every 7 minutes')"},
+		{"every 5 hours", "ambiguous phrase: every 5 hours restarts at every day (use a divisor of 24) (in 'every 5 hours')"},
+		{"every 2 days", "ambiguous phrase: every 2 days restarts at every month or year (in 'every 2 days')"},
+		{"every other week", "ambiguous phrase: 'every other' cannot be written in cron (in 'every other week')"},
+		{"weekly", "ambiguous phrase: 'weekly' does not tell the day (e.g. 'every monday') (in 'weekly')"},
+		{"on the 1st and on mondays", "ambiguous phrase: days are set twice (use ';' for separate schedules) (in 'on the 1st and on mondays')"},
+		{"every hour at 9:30", "ambiguous phrase: 'every hour' with times (in 'every hour at 9:30')"},
+		{"from 9 to 17", "ambiguous phrase: hours without a frequency (e.g. 'every hour') (in 'from 9 to 17')"},
+		{"at 13pm", "invalid time '13pm' (in 'at 13pm')"},
+		{"at 9 on the", "expected '<day>' but got end of phrase (in 'at 9 on the')"},
+		{"every day at tea time", "expected '<time>' but got 'tea' at word 4 (in 'every day at tea time')"},
+		{"in february on the 30th", "the 30th does not exist in february (in 'in february on the 30th')"},
+		{"on the 15th and 31st in april and june", "the 31st does not exist in april and june (in 'on the 15th and 31st in april and june')"},
+		{"on the weekday nearest the 31st of september", "the 31st does not exist in september (in 'on the weekday nearest the 31st of september')"},
+		{"on the 5th friday of the month", "ambiguous phrase: the 5th friday does not exist in every month (use 'the last friday') (in 'on the 5th friday of the month')"},
+		{"on the fifth monday", "ambiguous phrase: the fifth monday does not exist in every month (use 'the last monday') (in 'on the fifth monday')"},
+		{"on the 6th friday", "the 6th friday does not exist in any month (in 'on the 6th friday')"},
+		{"foo", "unexpected 'foo' at word 1 (in 'foo')"},
+		{"", "empty schedule"},
+	}
+
+	for _, t := range tt {
+		_, err := cronplan.FromText(t.text)
+		assert.EqualError(err, t.expected, t.text)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		exp      string
+		expected string
+	}{
+		{"30 9,15 ? * MON-FRI *", "at 9:30 and 15:30 on weekdays"},
+		{"0 0 ? * MON#1 *", "at midnight on the first monday of the month"},
+		{"*/15 9-17 ? * MON-FRI *", "every 15 minutes from 9 to 17 on weekdays"},
+		{"0 12 ? * SAT,SUN *", "at noon on weekends"},
+		{"* * * * ? *", "every minute"},
+		{"30 * * * ? *", "every hour at minute 30"},
+		{"0 */6 * * ? *", "every 6 hours"},
+		{"0 9-17 * * ? *", "every hour from 9 to 17"},
+		{"0 22 ? * FRIL *", "at 22:00 on the last friday of the month"},
+		{"59 23 L * ? *", "at 23:59 on the last day of the month"},
+		{"0 0 L-3 * ? *", "at midnight 3 days before the last day of the month"},
+		{"0 6 15W * ? *", "at 6:00 on the weekday nearest the 15th of the month"},
+		{"0 9 LW * ? 2025", "at 9:00 on the last weekday of the month in 2025"},
+		{"0 12 1,2,3,22 JAN ? *", "at noon on the 1st, 2nd, 3rd and 22nd of january"},
+		{"0 7 * MAR-MAY ? 2025,2026", "at 7:00 from march to may in 2025 and 2026"},
+		{"5-10,50-55 22-2 ? * 2#1 2025-2030", "minute(5-10,50-55) hour(22-2) on the first monday of the month year(2025-2030)"},
+		{"0 0 * JAN,MAR ? *", "at midnight in january and march"},
+		{"0 0 1-5 * ? *", "at midnight day-of-month(1-5)"},
+		{"0 0 ? * MON,2#1 *", "at midnight day-of-week(MON,MON#1)"},
+		{"0 0 ? * FRI#5 *", "at midnight day-of-week(FRI#5)"},
+	}
+
+	for _, t := range tt {
+		cron, err := cronplan.Parse(t.exp)
+		assert.NoError(err)
+		assert.Equal(t.expected, cron.Describe(), t.exp)
+
+		// round trip
+		exprs, err := cronplan.FromText(cron.Describe())
+
+		if assert.NoError(err, t.exp) && assert.Len(exprs, 1, t.exp) {
+			assert.Equal(cron.String(), exprs[0].String())
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package crontab_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/crontab"
+)
+
+func exprStrings(exprs []*cronplan.Expression) []string {
+	ss := []string{}
+
+	for _, e := range exprs {
+		ss = append(ss, e.String())
+	}
+
+	return ss
+}
+
+func TestConvert(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		schedule string
+		expected []string
+	}{
+		{"* * * * *", []string{"* * * * ? *"}},
+		{"*/15 9-17 * * 1-5", []string{"*/15 9-17 ? * MON-FRI *"}},
+		{"0 0 1 * *", []string{"0 0 1 * ? *"}},
+		{"0 0 */2 * *", []string{"0 0 */2 * ? *"}},
+		{"0 0 * * */2", []string{"0 0 ? * */2 *"}},
+		{"30 2 1,15 * MON", []string{"30 2 1,15 * ? *", "30 2 ? * MON *"}},
+		{"0 0 * * 0", []string{"0 0 ? * SUN *"}},
+		{"0 0 * * 7", []string{"0 0 ? * SUN *"}},
+		{"0 0 * * 5-7", []string{"0 0 ? * FRI,SAT,SUN *"}},
+		{"0 0 * * 0-7", []string{"0 0 ? * SUN,MON,TUE,WED,THU,FRI,SAT *"}},
+		{"0 0 * * 1-5/2", []string{"0 0 ? * MON-FRI/2 *"}},
+		{"0 0 * * 1/2", []string{"0 0 ? * MON,WED,FRI,SUN *"}},
+		{"0 0 * jan,jul sat", []string{"0 0 ? JAN,JUL SAT *"}},
+		{"@yearly", []string{"0 0 1 JAN ? *"}},
+		{"@annually", []string{"0 0 1 JAN ? *"}},
+		{"@monthly", []string{"0 0 1 * ? *"}},
+		{"@weekly", []string{"0 0 ? * SUN *"}},
+		{"@daily", []string{"0 0 * * ? *"}},
+		{"@midnight", []string{"0 0 * * ? *"}},
+		{"@HOURLY", []string{"0 * * * ? *"}},
+	}
+
+	for _, t := range tt {
+		exprs, err := crontab.Convert(t.schedule)
+		assert.NoError(err, t.schedule)
+		assert.Equal(t.expected, exprStrings(exprs), t.schedule)
+	}
+}
+
+func TestConvertErr(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		schedule string
+		expected string
+	}{
+		{"@reboot", "'@reboot' cannot be converted to EventBridge expressions"},
+		{"0 0 */2 * 1", "'0 0 */2 * 1' cannot be converted to EventBridge expressions: day-of-month and day-of-week are combined with AND"},
+		{"0 0 * *", "'0 0 * *' must have five fields"},
+		{"0 0 * * 8", "invalid day-of-week in '0 0 * * 8': day-of-week must be 0-7 (value=8)"},
+		{"0 0 * * 5-1", "invalid day-of-week in '0 0 * * 5-1': invalid range '5-1'"},
+		{"0 24 * * *", "'0 24 * * *' cannot be converted to EventBridge expressions: 1:3: hour must be 0-23 (value=24)"},
+	}
+
+	for _, t := range tt {
+		_, err := crontab.Convert(t.schedule)
+		assert.EqualError(err, t.expected, t.schedule)
+	}
+}
+
+func TestConvertKubernetes(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		schedule string
+		expected []string
+		tz       string
+	}{
+		{"*/15 9-17 * * 1-5", []string{"*/15 9-17 ? * MON-FRI *"}, ""},
+		{"0 0 */2 * 1", []string{"0 0 */2 * ? *", "0 0 ? * MON *"}, ""},
+		{"0 0 ? * 1", []string{"0 0 ? * MON *"}, ""},
+		{"0 0 * * 1/2", []string{"0 0 ? * MON-SAT/2 *"}, ""},
+		{"0 0 * * sun-sat", []string{"0 0 ? * SUN-SAT *"}, ""},
+		{"@weekly", []string{"0 0 ? * SUN *"}, ""},
+		{"CRON_TZ=Asia/Tokyo 0 9 * * *", []string{"0 9 * * ? *"}, "Asia/Tokyo"},
+		{"TZ=UTC @daily", []string{"0 0 * * ? *"}, "UTC"},
+	}
+
+	for _, t := range tt {
+		exprs, tz, err := crontab.ConvertKubernetes(t.schedule)
+		assert.NoError(err, t.schedule)
+		assert.Equal(t.expected, exprStrings(exprs), t.schedule)
+		assert.Equal(t.tz, tz, t.schedule)
+	}
+
+	tt2 := []struct {
+		schedule string
+		expected string
+	}{
+		{"0 0 * * 7", "invalid day-of-week in '0 0 * * 7': day-of-week must be 0-6 (value=7)"},
+		{"0 0 L * *", "invalid day-of-month in '0 0 L * *': 'L'"},
+		{"0 0 * * 5#2", "invalid day-of-week in '0 0 * * 5#2': cannot convert to weekday from 5#2"},
+		{"@every 1h", "'@every 1h' cannot be converted to EventBridge expressions"},
+	}
+
+	for _, t := range tt2 {
+		_, _, err := crontab.ConvertKubernetes(t.schedule)
+		assert.EqualError(err, t.expected, t.schedule)
+	}
+}
+
+// The split expressions fire on the days that the crontab schedule fires on (OR of day-of-month and day-of-week).
+func TestConvertOr(t *testing.T) {
+	assert := assert.New(t)
+	exprs, err := crontab.Convert("0 12 1,15 * FRI")
+	assert.NoError(err)
+
+	for d := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC); d.Year() == 2024; d = d.AddDate(0, 0, 1) {
+		expected := d.Day() == 1 || d.Day() == 15 || d.Weekday() == time.Friday
+		matched := false
+
+		for _, e := range exprs {
+			matched = matched || e.Match(d)
+		}
+
+		assert.Equal(expected, matched, d)
+	}
+}
+
+func TestRead(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `# m h dom mon dow command
+SHELL=/bin/bash
+MAILTO="ops@example.com"
+
+17 *  * * *   cd / && run-parts --report /etc/cron.hourly
+30 2  1,15 * MON /usr/local/bin/backup.sh --full
+@reboot /opt/app/bin/start
+CRON_TZ = Asia/Tokyo
+@daily  /usr/sbin/logrotate /etc/logrotate.conf
+0 0 * *
+`
+
+	entries, err := crontab.Read(strings.NewReader(src), false)
+	assert.EqualError(err, "line 7: '@reboot' cannot be converted to EventBridge expressions\nline 10: too few fields: 0 0 * *")
+	var lineErr *crontab.Error
+	assert.True(errors.As(err, &lineErr))
+	assert.Equal(7, lineErr.Line)
+
+	assert.Len(entries, 3)
+	env := map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"}
+
+	assert.Equal(5, entries[0].Line)
+	assert.Equal("17 * * * *", entries[0].Schedule)
+	assert.Equal("cd / && run-parts --report /etc/cron.hourly", entries[0].Command)
+	assert.Equal("cd-run-parts-report-etc-cron.hourly", entries[0].Name())
+	assert.Equal(env, entries[0].Env)
+	assert.Equal([]string{"17 * * * ? *"}, exprStrings(entries[0].Expressions))
+
+	assert.Equal("backup.sh-full", entries[1].Name())
+	assert.Equal([]string{"30 2 1,15 * ? *", "30 2 ? * MON *"}, exprStrings(entries[1].Expressions))
+
+	assert.Equal("@daily", entries[2].Schedule)
+	assert.Equal("Asia/Tokyo", entries[2].Timezone)
+	assert.Equal("Asia/Tokyo", entries[2].Env["CRON_TZ"])
+	assert.Equal("", entries[0].Env["CRON_TZ"])
+}
+
+func TestReadSystem(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `17 * * * * root  cd / && run-parts --report /etc/cron.hourly
+@weekly    app   /opt/app/bin/cleanup
+0 9 * * 1-5 app
+`
+
+	entries, err := crontab.Read(strings.NewReader(src), true)
+	assert.EqualError(err, "line 3: too few fields: 0 9 * * 1-5 app")
+	assert.Len(entries, 2)
+	assert.Equal("root", entries[0].User)
+	assert.Equal("cd / && run-parts --report /etc/cron.hourly", entries[0].Command)
+	assert.Equal("app", entries[1].User)
+	assert.Equal("/opt/app/bin/cleanup", entries[1].Command)
+	assert.Equal("cleanup", entries[1].Name())
+}
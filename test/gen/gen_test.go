@@ -0,0 +1,127 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/gen"
+)
+
+func TestGeneratorSeed(t *testing.T) {
+	assert := assert.New(t)
+	g1 := gen.NewGenerator(1, nil)
+	g2 := gen.NewGenerator(1, nil)
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(g1.NextString(), g2.NextString())
+	}
+}
+
+func TestGeneratorValid(t *testing.T) {
+	assert := assert.New(t)
+	g := gen.NewGenerator(1, &gen.Options{EdgeBias: 0.5, MaxItems: 4})
+
+	for i := 0; i < 1000; i++ {
+		exp := g.NextString()
+		_, err := cronplan.Parse(exp)
+		assert.NoError(err, exp)
+	}
+}
+
+func TestGeneratorEdgeBias(t *testing.T) {
+	assert := assert.New(t)
+
+	countEdges := func(bias float64) int {
+		g := gen.NewGenerator(1, &gen.Options{EdgeBias: bias, MaxItems: 1})
+		edges := 0
+
+		for i := 0; i < 1000; i++ {
+			exp := g.NextString()
+
+			for _, s := range []string{"L-30", "31W", "1W", "LW", "#5", "/0", "2199"} {
+				if strings.Contains(exp, s) {
+					edges++
+					break
+				}
+			}
+		}
+
+		return edges
+	}
+
+	assert.Greater(countEdges(1), countEdges(0)*3)
+}
+
+func checkExpression(t *testing.T, cron *cronplan.Expression, from time.Time) {
+	exp := cron.String()
+
+	// round trip
+	parsed, err := cronplan.Parse(exp)
+
+	if err != nil {
+		t.Fatalf("%s: %s", exp, err)
+	}
+
+	if parsed.String() != exp {
+		t.Fatalf("%s: round trip: %s", exp, parsed)
+	}
+
+	// Next agrees with Match
+	next := cron.Next(from)
+
+	if next.IsZero() {
+		for tm := from; tm.Before(from.Add(24 * time.Hour)); tm = tm.Add(time.Minute) {
+			if cron.Match(tm) {
+				t.Fatalf("%s: no next from %s, but %s matches", exp, from, tm)
+			}
+		}
+
+		return
+	}
+
+	if !cron.Match(next) {
+		t.Fatalf("%s: next %s from %s does not match", exp, next, from)
+	}
+
+	if next.Before(from) {
+		t.Fatalf("%s: next %s is before %s", exp, next, from)
+	}
+
+	for tm := from; tm.Before(next) && tm.Before(from.Add(24*time.Hour)); tm = tm.Add(time.Minute) {
+		if cron.Match(tm) {
+			t.Fatalf("%s: %s matches before next %s", exp, tm, next)
+		}
+	}
+}
+
+func TestQuick(t *testing.T) {
+	from := time.Date(2024, 2, 28, 22, 0, 0, 0, time.UTC)
+
+	err := quick.Check(func(e gen.Expression) bool {
+		checkExpression(t, e.Expression, from)
+		return true
+	}, &quick.Config{MaxCount: 200})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func FuzzExpression(f *testing.F) {
+	f.Add(int64(1), int64(1709157600), 0.2)
+	f.Add(int64(2), int64(1735689540), 0.5)
+	f.Add(int64(3), int64(0), 1.0)
+
+	f.Fuzz(func(t *testing.T, seed int64, unix int64, bias float64) {
+		if unix < 0 || unix > 7258118400 { // 2200-01-01
+			t.Skip()
+		}
+
+		g := gen.NewGenerator(seed, &gen.Options{EdgeBias: bias, MaxItems: gen.DefaultMaxItems})
+		checkExpression(t, g.Next(), time.Unix(unix, 0).UTC().Truncate(time.Minute))
+	})
+}
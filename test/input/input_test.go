@@ -0,0 +1,213 @@
+package input_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2/internal/input"
+)
+
+type entry struct {
+	file string
+	line int
+	name string
+	expr string
+}
+
+func entries(es []*input.Entry) []entry {
+	list := []entry{}
+
+	for _, e := range es {
+		list = append(list, entry{e.File, e.Line, e.Name, e.Cron.String()})
+	}
+
+	return list
+}
+
+func TestParseLines(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `# nightly jobs
+0 10 * * ? *
+backup  0 3 * * ? *   # full backup
+report  cron(0 9 ? * MON#2 *)
+cron(15 12 * * ? *)
+
+broken 0 25 * * ? *
+`
+
+	es, err := input.Parse("jobs.txt", []byte(src))
+	assert.EqualError(err, "jobs.txt:7: 1:3: hour must be 0-23 (value=25)")
+	var lineErr *input.Error
+	assert.True(errors.As(err, &lineErr))
+	assert.Equal(7, lineErr.Line)
+	assert.Equal([]entry{
+		{"jobs.txt", 2, "", "0 10 * * ? *"},
+		{"jobs.txt", 3, "backup", "0 3 * * ? *"},
+		{"jobs.txt", 4, "report", "0 9 ? * MON#2 *"},
+		{"jobs.txt", 5, "", "15 12 * * ? *"},
+	}, entries(es))
+}
+
+func TestParseCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		filename string
+		src      string
+	}{
+		{"jobs.csv", "backup,0 3 * * ? *\n# comment\nreport,\"cron(0 9 ? * MON-FRI *)\"\n"},
+		{input.Stdin, "name,expression,time\nbackup,0 3 * * ? *,2024-11-11T03:00:00Z\nreport,0 9 ? * MON-FRI *,2024-11-11T09:00:00Z\n"},
+	}
+
+	for _, t := range tt {
+		es, err := input.Parse(t.filename, []byte(t.src))
+		assert.NoError(err)
+		assert.Len(es, 2)
+		assert.Equal("backup", es[0].Name)
+		assert.Equal("0 3 * * ? *", es[0].Expr)
+		assert.Equal("report", es[1].Name)
+		assert.Equal("0 9 ? * MON-FRI *", es[1].Expr)
+		assert.Equal(3, es[1].Line)
+	}
+
+	es, err := input.Parse("exprs.csv", []byte("0 10 * * ? *\n0 0 * * * *\n"))
+	assert.EqualError(err, "exprs.csv:2: either day-of-month or day-of-week must be '?'")
+	assert.Equal([]entry{{"exprs.csv", 1, "", "0 10 * * ? *"}}, entries(es))
+}
+
+func TestParseList(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		filename string
+		src      string
+	}{
+		{"jobs.json", `[{"name": "backup", "expression": "cron(0 3 * * ? *)"}, "report 0 9 ? * MON-FRI *", "0 10 * * ? *"]`},
+		{"jobs.yml", "- name: backup\n  expression: 0 3 * * ? *\n- report 0 9 ? * MON-FRI *\n- '0 10 * * ? *'\n"},
+		{input.Stdin, "# jobs\n- name: backup\n  expression: 0 3 * * ? *\n- report 0 9 ? * MON-FRI *\n- '0 10 * * ? *'\n"},
+	}
+
+	for _, t := range tt {
+		es, err := input.Parse(t.filename, []byte(t.src))
+		assert.NoError(err, t.filename)
+		assert.Len(es, 3, t.filename)
+		assert.Equal("backup", es[0].Name)
+		assert.Equal("0 3 * * ? *", es[0].Expr)
+		assert.Equal("report", es[1].Name)
+		assert.Equal("", es[2].Name)
+		assert.Equal("0 10 * * ? *", es[2].Expr)
+	}
+
+	_, err := input.Parse("jobs.yml", []byte("- name: backup\n- [1]\n- 0 0 * * * *\n"))
+	assert.EqualError(err, "jobs.yml:1: 'expression' is required\n"+
+		"jobs.yml:2: must be an expression or an object that has 'expression'\n"+
+		"jobs.yml:3: either day-of-month or day-of-week must be '?'")
+
+	_, err = input.Parse("jobs.yml", []byte("backup: 0 3 * * ? *\n"))
+	assert.EqualError(err, "jobs.yml:1: must be a list of expressions")
+}
+
+func TestInclude(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.txt":        "0 10 * * ? *\n@include jobs/*.txt\n@include more.csv\n@include missing.txt\n",
+		"jobs/a.txt":      "a 0 1 * * ? *\n",
+		"jobs/b.txt":      "b 0 2 * * ? *\n@include ../main.txt\nbroken 0 25 * * ? *\n",
+		"more.csv":        "c,0 3 * * ? *\n",
+		"jobs/ignore.yml": "- d 0 4 * * ? *\n",
+	}
+
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(os.WriteFile(path, []byte(src), 0o644))
+	}
+
+	es, err := input.Load(filepath.Join(dir, "main.txt"))
+	msgs := strings.Split(strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""), "\n")
+	assert.Equal([]string{
+		"jobs/b.txt:2: include cycle: main.txt",
+		"jobs/b.txt:3: 1:3: hour must be 0-23 (value=25)",
+		"main.txt:4: no files match 'missing.txt'",
+	}, msgs)
+
+	names := []string{}
+
+	for _, e := range es {
+		names = append(names, e.Name)
+	}
+
+	assert.Equal([]string{"", "a", "b", "c"}, names)
+}
+
+func TestParseRaw(t *testing.T) {
+	assert := assert.New(t)
+
+	src := "- name: backup\n  expression: 0 25 * * ? *\n- report 0 9 ? * L *\n- [1]\n"
+	es, err := input.ParseRaw("jobs.yml", []byte(src))
+	assert.EqualError(err, "jobs.yml:4: must be an expression or an object that has 'expression'")
+
+	// the expressions are not parsed, and the line of an object is the line of its expression
+	assert.Equal([]*input.Entry{
+		{File: "jobs.yml", Line: 2, Name: "backup", Expr: "0 25 * * ? *"},
+		{File: "jobs.yml", Line: 3, Name: "report", Expr: "0 9 ? * L *"},
+	}, es)
+}
+
+func TestParseArg(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		arg  string
+		name string
+		expr string
+	}{
+		{"0 10 * * ? *", "", "0 10 * * ? *"},
+		{"cron(0 10 * * ? *)", "", "0 10 * * ? *"},
+		{"nightly cron(0 10 * * ? *)", "nightly", "0 10 * * ? *"},
+	}
+
+	for _, t := range tt {
+		e, err := input.ParseArg(t.arg)
+		assert.NoError(err)
+		assert.Equal(t.name, e.Name)
+		assert.Equal(t.expr, e.Expr)
+		assert.Equal(t.expr, e.Cron.String())
+	}
+
+	_, err := input.ParseArg("0 10 * * *")
+	assert.Error(err)
+}
+
+func TestIsAWSExport(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		src      string
+		expected bool
+	}{
+		{`{"Rules": []}`, true},
+		{`[{"Name": "once", "ScheduleExpression": "at(2024-11-11T12:00:00)"}]`, true},
+		{`[{"name": "backup", "expression": "0 3 * * ? *"}]`, false},
+		{"0 10 * * ? *", false},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, input.IsAWSExport([]byte(t.src)), t.src)
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	assert := assert.New(t)
+	line := strings.Repeat("x", 100*1024)
+	scanner := input.NewScanner(strings.NewReader(line + "\n"))
+	assert.True(scanner.Scan())
+	assert.Equal(line, scanner.Text())
+}
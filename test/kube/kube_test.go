@@ -0,0 +1,142 @@
+package kube_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2/kube"
+)
+
+func TestRead(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+  namespace: batch
+spec:
+  schedule: "0 9 * * 1-5"
+  timeZone: Asia/Tokyo
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: report
+              image: report:latest
+          restartPolicy: OnFailure
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: report-config
+data:
+  schedule: daily
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: batch/v1
+    kind: CronJob
+    metadata:
+      name: cleanup
+    spec:
+      schedule: "CRON_TZ=America/New_York 30 1 * * *"
+      suspend: true
+`
+
+	jobs, err := kube.Read(strings.NewReader(src))
+	assert.NoError(err)
+	assert.Equal([]*kube.CronJob{
+		{Line: 8, Namespace: "batch", Name: "report", Schedule: "0 9 * * 1-5", TimeZone: "Asia/Tokyo", ConcurrencyPolicy: kube.ConcurrencyPolicyForbid},
+		{Line: 35, Name: "cleanup", Schedule: "CRON_TZ=America/New_York 30 1 * * *", Suspend: true, ConcurrencyPolicy: kube.ConcurrencyPolicyAllow},
+	}, jobs)
+
+	assert.Equal("batch/report", jobs[0].FullName())
+	assert.Equal("cleanup", jobs[1].FullName())
+
+	loc, err := jobs[1].Location()
+	assert.NoError(err)
+	assert.Equal("America/New_York", loc.String())
+}
+
+func TestReadErr(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		src      string
+		expected string
+	}{
+		{"apiVersion: batch/v1\nkind: CronJob\nmetadata:\n  name: report\nspec: {}\n", "CronJob 'report' has no spec.schedule"},
+		{"apiVersion: batch/v1\nkind: CronJob\nspec:\n  schedule: [", "failed to parse manifest: yaml: line 4: did not find expected node content"},
+	}
+
+	for _, t := range tt {
+		_, err := kube.Read(strings.NewReader(t.src))
+		assert.EqualError(err, t.expected)
+	}
+}
+
+func TestIsManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		src      string
+		expected bool
+	}{
+		{"apiVersion: batch/v1\nkind: CronJob\n", true},
+		{`{"apiVersion": "v1", "kind": "List", "items": []}`, true},
+		{`{"Rules": []}`, false},
+		{"0 10 * * ? *\n", false},
+		{"nightly 0 3 * * ? *\nreport 0 9 ? * MON-FRI *\n", false},
+		{"", false},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, kube.IsManifest([]byte(t.src)), t.src)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	assert := assert.New(t)
+	from := time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 11, 13, 0, 0, 0, 0, time.UTC)
+
+	// 09:00 JST is 00:00 UTC
+	job := &kube.CronJob{Name: "report", Schedule: "0 9 * * 1-5", TimeZone: "Asia/Tokyo"}
+	times, err := job.Between(from, to)
+	assert.NoError(err)
+	assert.Equal([]time.Time{
+		time.Date(2024, 11, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 13, 0, 0, 0, 0, time.UTC),
+	}, times)
+
+	// day-of-month and day-of-week are combined with OR
+	job = &kube.CronJob{Name: "odd", Schedule: "TZ=UTC 0 12 */2 * TUE"}
+	times, err = job.Between(from, from.AddDate(0, 0, 4))
+	assert.NoError(err)
+	assert.Equal([]time.Time{
+		time.Date(2024, 11, 11, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 12, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 11, 13, 12, 0, 0, 0, time.UTC),
+	}, times)
+
+	// 2024-04-01 is a Monday, so it matches both expressions but runs once
+	job = &kube.CronJob{Name: "monthly", Schedule: "0 0 1 * MON"}
+	times, err = job.Between(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC))
+	assert.NoError(err)
+	assert.Equal([]time.Time{
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC),
+	}, times)
+
+	job = &kube.CronJob{Name: "both", Schedule: "CRON_TZ=UTC @daily", TimeZone: "Asia/Tokyo"}
+	_, err = job.Between(from, to)
+	assert.EqualError(err, "cannot use both spec.timeZone and TZ or CRON_TZ in spec.schedule")
+}
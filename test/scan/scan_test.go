@@ -0,0 +1,267 @@
+package scan_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2/scan"
+)
+
+func TestParseTerraform(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+variable "schedule" {
+  default = "rate(1 hour)"
+}
+
+resource "aws_cloudwatch_event_rule" "nightly" {
+  name                = "nightly"
+  schedule_expression = "cron(0 3 * * ? *)"
+}
+
+resource "aws_cloudwatch_event_rule" "from_var" {
+  schedule_expression = var.schedule
+}
+
+resource "aws_cloudwatch_event_rule" "pattern" {
+  event_pattern = jsonencode({ source = ["aws.ec2"] })
+}
+
+resource "aws_scheduler_schedule" "report" {
+  schedule_expression          = "cron(0 9 ? * MON-FRI *)"
+  schedule_expression_timezone = "Asia/Tokyo"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+}
+`
+
+	schedules, err := scan.Parse("main.tf", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "main.tf", Line: 8, Resource: "aws_cloudwatch_event_rule.nightly", Source: scan.SourceTerraform, Expression: "cron(0 3 * * ? *)"},
+		{File: "main.tf", Line: 20, Resource: "aws_scheduler_schedule.report", Source: scan.SourceTerraform, Expression: "cron(0 9 ? * MON-FRI *)", Timezone: "Asia/Tokyo"},
+	}, schedules)
+
+	src = `{
+  "resource": {
+    "aws_cloudwatch_event_rule": {
+      "poll": {
+        "schedule_expression": "rate(5 minutes)"
+      }
+    }
+  }
+}`
+
+	schedules, err = scan.Parse("main.tf.json", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "main.tf.json", Line: 5, Resource: "aws_cloudwatch_event_rule.poll", Source: scan.SourceTerraform, Expression: "rate(5 minutes)"},
+	}, schedules)
+
+	_, err = scan.Parse("broken.tf", []byte(`resource "aws_cloudwatch_event_rule" {`))
+	assert.ErrorContains(err, "broken.tf:1")
+}
+
+func TestParseCloudFormation(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+AWSTemplateFormatVersion: "2010-09-09"
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  NightlyRule:
+    Type: AWS::Events::Rule
+    Properties:
+      ScheduleExpression: cron(0 3 * * ? *)
+  SubRule:
+    Type: AWS::Events::Rule
+    Properties:
+      ScheduleExpression: !Sub "rate(${Minutes} minutes)"
+  ReportSchedule:
+    Type: AWS::Scheduler::Schedule
+    Properties:
+      ScheduleExpression: "cron(0 9 ? * MON-FRI *)"
+      ScheduleExpressionTimezone: Asia/Tokyo
+  Worker:
+    Type: AWS::Serverless::Function
+    Properties:
+      Events:
+        Poll:
+          Type: Schedule
+          Properties:
+            Schedule: rate(10 minutes)
+        Daily:
+          Type: ScheduleV2
+          Properties:
+            ScheduleExpression: cron(30 0 * * ? *)
+            ScheduleExpressionTimezone: UTC
+        Api:
+          Type: Api
+          Properties:
+            Path: /
+            Method: get
+  Bucket:
+    Type: AWS::S3::Bucket
+`
+
+	schedules, err := scan.Parse("template.yaml", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "template.yaml", Line: 8, Resource: "NightlyRule", Source: scan.SourceCloudFormation, Expression: "cron(0 3 * * ? *)"},
+		{File: "template.yaml", Line: 16, Resource: "ReportSchedule", Source: scan.SourceCloudFormation, Expression: "cron(0 9 ? * MON-FRI *)", Timezone: "Asia/Tokyo"},
+		{File: "template.yaml", Line: 25, Resource: "Worker.Poll", Source: scan.SourceSAM, Expression: "rate(10 minutes)"},
+		{File: "template.yaml", Line: 29, Resource: "Worker.Daily", Source: scan.SourceSAM, Expression: "cron(30 0 * * ? *)", Timezone: "UTC"},
+	}, schedules)
+
+	src = `{
+  "Resources": {
+    "NightlyRule": {
+      "Type": "AWS::Events::Rule",
+      "Properties": {
+        "ScheduleExpression": "cron(0 3 * * ? *)"
+      }
+    },
+    "RefRule": {
+      "Type": "AWS::Events::Rule",
+      "Properties": {
+        "ScheduleExpression": { "Ref": "Schedule" }
+      }
+    }
+  }
+}`
+
+	schedules, err = scan.Parse("template.json", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "template.json", Line: 6, Resource: "NightlyRule", Source: scan.SourceCloudFormation, Expression: "cron(0 3 * * ? *)"},
+	}, schedules)
+}
+
+func TestParseServerless(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+service: jobs
+functions:
+  poll:
+    handler: poll.handler
+    events:
+      - schedule: rate(10 minutes)
+  report:
+    handler: report.handler
+    events:
+      - http: GET /report
+      - schedule:
+          rate:
+            - cron(0 9 ? * MON-FRI *)
+            - cron(0 12 ? * SAT *)
+          timezone: Asia/Tokyo
+          enabled: true
+resources:
+  Resources:
+    CleanupRule:
+      Type: AWS::Events::Rule
+      Properties:
+        ScheduleExpression: cron(0 0 1 * ? *)
+`
+
+	schedules, err := scan.Parse("serverless.yml", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "serverless.yml", Line: 7, Resource: "poll", Source: scan.SourceServerless, Expression: "rate(10 minutes)"},
+		{File: "serverless.yml", Line: 14, Resource: "report.1", Source: scan.SourceServerless, Expression: "cron(0 9 ? * MON-FRI *)", Timezone: "Asia/Tokyo"},
+		{File: "serverless.yml", Line: 15, Resource: "report.2", Source: scan.SourceServerless, Expression: "cron(0 12 ? * SAT *)", Timezone: "Asia/Tokyo"},
+		{File: "serverless.yml", Line: 23, Resource: "CleanupRule", Source: scan.SourceCloudFormation, Expression: "cron(0 0 1 * ? *)"},
+	}, schedules)
+}
+
+func TestParseKubernetes(t *testing.T) {
+	assert := assert.New(t)
+
+	src := `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+  namespace: batch
+spec:
+  schedule: "0 9 * * 1-5"
+  timeZone: Asia/Tokyo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: batch/v1
+    kind: CronJob
+    metadata:
+      name: cleanup
+    spec:
+      schedule: "@daily"
+`
+
+	schedules, err := scan.Parse("cronjobs.yaml", []byte(src))
+	assert.NoError(err)
+	assert.Equal([]*scan.Schedule{
+		{File: "cronjobs.yaml", Line: 8, Resource: "batch/report", Source: scan.SourceKubernetes, Expression: "0 9 * * 1-5", Timezone: "Asia/Tokyo"},
+		{File: "cronjobs.yaml", Line: 24, Resource: "cleanup", Source: scan.SourceKubernetes, Expression: "@daily"},
+	}, schedules)
+}
+
+func TestParseIgnored(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		filename string
+		src      string
+	}{
+		{"package.json", `{"name": "app", "scripts": {"test": "jest"}}`},
+		{".github/workflows/ci.yml", "on:\n  schedule:\n    - cron: '0 0 * * *'\n"},
+		{"README.md", "schedule_expression = \"cron(0 3 * * ? *)\""},
+		{"empty.yml", ""},
+	}
+
+	for _, t := range tt {
+		schedules, err := scan.Parse(t.filename, []byte(t.src))
+		assert.NoError(err, t.filename)
+		assert.Empty(schedules, t.filename)
+	}
+}
+
+func TestDir(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"infra/main.tf":                      `resource "aws_cloudwatch_event_rule" "a" { schedule_expression = "rate(1 hour)" }`,
+		"infra/.terraform/modules/m/main.tf": `resource "aws_cloudwatch_event_rule" "b" { schedule_expression = "rate(1 hour)" }`,
+		"app/serverless.yml":                 "service: app\nfunctions:\n  f:\n    events:\n      - schedule: rate(1 day)\n",
+		"app/node_modules/x/serverless.yml":  "service: x\nfunctions:\n  g:\n    events:\n      - schedule: rate(1 day)\n",
+		"broken.yml":                         "a: [",
+	}
+
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(os.WriteFile(path, []byte(src), 0o644))
+	}
+
+	schedules, err := scan.Dir(dir)
+	assert.ErrorContains(err, "broken.yml")
+	resources := []string{}
+
+	for _, s := range schedules {
+		resources = append(resources, s.Resource)
+	}
+
+	assert.Equal([]string{"f", "aws_cloudwatch_event_rule.a"}, resources)
+}
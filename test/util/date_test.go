@@ -518,3 +518,45 @@ func TestLastWeekdayOfMonth(t *testing.T) {
 		assert.Equal(t.expected, util.LastWeekdayOfMonth(t.tm), t.tm)
 	}
 }
+
+func TestDaysIn(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		year     int
+		month    time.Month
+		expected int
+	}{
+		{2023, time.January, 31},
+		{2023, time.February, 28},
+		{2024, time.February, 29},
+		{2100, time.February, 28},
+		{2000, time.February, 29},
+		{2023, time.April, 30},
+		{2023, time.December, 31},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, util.DaysIn(t.year, t.month), t)
+	}
+}
+
+func TestWeekdayOf(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		tm       time.Time
+		day      int
+		expected time.Weekday
+	}{
+		{time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC), 1, time.Thursday},
+		{time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC), 15, time.Thursday},
+		{time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC), 29, time.Thursday},
+		{time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC), 4, time.Sunday},
+		{time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC), 3, time.Saturday},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, util.WeekdayOf(t.tm, t.day), t)
+	}
+}
@@ -117,6 +117,9 @@ func TestListDayOfMonth(t *testing.T) {
 		{time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), 1, 28, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28}},
 		{time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), 28, 1, []int{28, 1}},
 		{time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), 2, 31, []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28}},
+		{time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), 30, 31, []int{}},
+		{time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), 30, 2, []int{1, 2}},
+		{time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), 31, 31, []int{}},
 	}
 
 	for _, t := range tt {
@@ -190,3 +193,31 @@ func TestLisYear(t *testing.T) {
 		assert.Equal(t.expected, actual, t)
 	}
 }
+
+func TestInRange(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		n        int
+		start    int
+		end      int
+		expected bool
+	}{
+		{5, 1, 10, true},
+		{1, 1, 10, true},
+		{10, 1, 10, true},
+		{0, 1, 10, false},
+		{11, 1, 10, false},
+		{5, 5, 5, true},
+		{50, 50, 10, true},
+		{59, 50, 10, true},
+		{0, 50, 10, true},
+		{10, 50, 10, true},
+		{11, 50, 10, false},
+		{49, 50, 10, false},
+	}
+
+	for _, t := range tt {
+		assert.Equal(t.expected, util.InRange(t.n, t.start, t.end), t)
+	}
+}
@@ -0,0 +1,105 @@
+package zone_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/winebarrel/cronplan/v2"
+	"github.com/winebarrel/cronplan/v2/internal/zone"
+)
+
+func TestParseOffset(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		offset   string
+		name     string
+		expected int
+	}{
+		{"+09:00", "UTC+09:00", 9 * 60 * 60},
+		{"+0530", "UTC+05:30", 5*60*60 + 30*60},
+		{"-3:30", "UTC-03:30", -(3*60*60 + 30*60)},
+		{"-8", "UTC-08:00", -8 * 60 * 60},
+		{"Z", "UTC", 0},
+	}
+
+	for _, t := range tt {
+		loc, err := zone.ParseOffset(t.offset)
+		assert.NoError(err)
+		name, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+		assert.Equal(t.name, name, t)
+		assert.Equal(t.expected, offset, t)
+	}
+}
+
+func TestParseOffsetError(t *testing.T) {
+	assert := assert.New(t)
+
+	tt := []struct {
+		offset   string
+		expected string
+	}{
+		{"09:00", "cannot convert to UTC offset from 09:00"},
+		{"+9:0", "cannot convert to UTC offset from +9:0"},
+		{"+15:00", "UTC offset must be -14:00 to +14:00 (value=+15:00)"},
+		{"+09:60", "UTC offset must be -14:00 to +14:00 (value=+09:60)"},
+	}
+
+	for _, t := range tt {
+		_, err := zone.ParseOffset(t.offset)
+		assert.EqualError(err, t.expected, t)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, evalLoc, err := zone.Load("Asia/Kolkata", "")
+	assert.NoError(err)
+	assert.Equal("Asia/Kolkata", loc.String())
+	assert.Equal(time.UTC, evalLoc)
+
+	loc, evalLoc, err = zone.Load("", "+05:30")
+	assert.NoError(err)
+	assert.Equal("UTC+05:30", loc.String())
+	assert.Equal(time.UTC, evalLoc)
+
+	loc, evalLoc, err = zone.Load("", "")
+	assert.NoError(err)
+	assert.Equal(time.Local, loc)
+	assert.Nil(evalLoc)
+
+	_, _, err = zone.Load("Asia/Tokyo", "+09:00")
+	assert.EqualError(err, "'-tz' and '-utc-offset' cannot be used together")
+
+	_, _, err = zone.Load("Mars/Olympus_Mons", "")
+	assert.Error(err)
+}
+
+func TestIn(t *testing.T) {
+	assert := assert.New(t)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(err)
+
+	local := time.Local
+	time.Local = tokyo
+	defer func() { time.Local = local }()
+
+	cron, err := cronplan.Parse("0 1 * * ? *")
+	assert.NoError(err)
+	tm, err := time.Parse(time.RFC3339, "2024-01-01T01:00:00Z")
+	assert.NoError(err)
+
+	// without '-tz' and '-utc-offset', the explicit offset is kept
+	_, evalLoc, err := zone.Load("", "")
+	assert.NoError(err)
+	assert.Equal(tm, zone.In(tm, evalLoc))
+	assert.True(cron.Match(zone.In(tm, evalLoc)))
+
+	_, evalLoc, err = zone.Load("Asia/Tokyo", "")
+	assert.NoError(err)
+	assert.Equal(time.UTC, zone.In(tm, evalLoc).Location())
+	assert.True(cron.Match(zone.In(tm, evalLoc)))
+}
@@ -0,0 +1,1271 @@
+package cronplan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winebarrel/cronplan/v2/internal/util"
+)
+
+var (
+	textTimeRegexp     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	textOrdinalRegexp  = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)$`)
+	textRawFieldRegexp = regexp.MustCompile(`^(minute|hour|day-of-month|month|day-of-week|year)\((\S+)\)$`)
+	textNumberRegexp   = regexp.MustCompile(`^\d+$`)
+	textYearsRegexp    = regexp.MustCompile(`^\d{4}(,\d{4})*$`)
+	textOrdinalWords   = []string{"first", "second", "third", "fourth", "fifth"}
+	textWeekdayNames   = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	textMonthNames     = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
+)
+
+// FromText parses an English phrase into expressions.
+// A phrase that cannot be written as one expression (e.g. "at 9:00 and 15:30")
+// results in multiple expressions, and phrases separated by ";" are parsed separately.
+//
+// A phrase is a sequence of the following clauses (case-insensitive):
+//
+//	every minute | every N minutes          (N must divide 60)
+//	every hour | every N hours | hourly     (N must divide 24)
+//	at minute M                             (with "every hour" or "every N hours")
+//	at 9:30 | at 9am | at 3:30pm | at noon | at midnight    ("," or "and" for a list)
+//	from 9 to 17 | between 9am and 5pm     (hours, inclusive)
+//	during business hours                   (from 9 to 17 on weekdays)
+//	every day | daily
+//	every weekday | every weekend | every monday | on mondays | on monday, tuesday and friday
+//	on weekdays | on weekends | from monday to friday
+//	every month | monthly                   (on the 1st)
+//	every year | yearly | annually          (on the 1st of january)
+//	on the 1st and 15th of the month
+//	on the last day of the month | 3 days before the last day of the month
+//	on the last weekday of the month | on the weekday nearest the 15th of the month
+//	on the first monday of the month | on the 2nd tuesday | on the last friday of the month    (first to fourth)
+//	in january and july | from january to march | in 2025 | from 2025 to 2027
+//	minute(5-10) | hour(22-2) | day-of-month(1-5) | month(*/3) | day-of-week(2#1) | year(2025)
+//
+// The time defaults to midnight and the day defaults to every day.
+// Ambiguous phrases (e.g. "every 7 minutes", "every 2 days", "weekly",
+// "on the 1st and on mondays") are errors.
+func FromText(text string) ([]*Expression, error) {
+	exprs := []*Expression{}
+	tokens := tokenizeText(text)
+
+	for len(tokens) > 0 {
+		i := 0
+
+		for i < len(tokens) && tokens[i] != ";" {
+			i++
+		}
+
+		if i > 0 {
+			p := &textParser{tokens: tokens[:i]}
+			es, err := p.parse()
+
+			if err != nil {
+				return nil, fmt.Errorf("%w (in '%s')", err, strings.Join(tokens[:i], " "))
+			}
+
+			exprs = append(exprs, es...)
+		}
+
+		if i < len(tokens) {
+			i++
+		}
+
+		tokens = tokens[i:]
+	}
+
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	return exprs, nil
+}
+
+func tokenizeText(text string) []string {
+	tokens := []string{}
+
+	for _, word := range strings.Fields(text) {
+		word = strings.ToLower(word)
+
+		if textRawFieldRegexp.MatchString(strings.TrimRight(word, ",;")) {
+			suffix := word[len(strings.TrimRight(word, ",;")):]
+			tokens = append(tokens, strings.TrimRight(word, ",;"))
+
+			for _, c := range suffix {
+				tokens = append(tokens, string(c))
+			}
+
+			continue
+		}
+
+		for word != "" {
+			i := strings.IndexAny(word, ",;")
+
+			if i < 0 {
+				tokens = append(tokens, word)
+				break
+			}
+
+			if i > 0 {
+				tokens = append(tokens, word[:i])
+			}
+
+			tokens = append(tokens, word[i:i+1])
+			word = word[i+1:]
+		}
+	}
+
+	return tokens
+}
+
+type textParser struct {
+	tokens []string
+	pos    int
+
+	// minute and hour set by a frequency (e.g. "every 15 minutes")
+	minute string
+	hour   string
+	freq   string
+	// minuteAt is set by "at minute M"
+	minuteAt string
+	// hours is set by "from H to H"
+	hours string
+	// times are the minutes of the day set by "at H:MM"
+	times []int
+
+	dom      string
+	dow      string
+	month    string
+	year     string
+	monthly  bool
+	yearly   bool
+	business bool
+}
+
+func (p *textParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *textParser) next() string {
+	tok := p.peek()
+
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *textParser) accept(words ...string) bool {
+	for _, w := range words {
+		if p.peek() == w {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *textParser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return p.unexpected(strings.Join(words, "' or '"))
+	}
+
+	return nil
+}
+
+func (p *textParser) unexpected(expected string) error {
+	tok := p.peek()
+
+	if expected == "" {
+		return fmt.Errorf("unexpected '%s' at word %d", tok, p.pos+1)
+	}
+
+	if tok == "" {
+		return fmt.Errorf("expected '%s' but got end of phrase", expected)
+	}
+
+	return fmt.Errorf("expected '%s' but got '%s' at word %d", expected, tok, p.pos+1)
+}
+
+func ambiguous(format string, a ...any) error {
+	return fmt.Errorf("ambiguous phrase: "+format, a...)
+}
+
+func (p *textParser) parse() ([]*Expression, error) {
+	for p.peek() != "" {
+		var err error
+		tok := p.peek()
+
+		switch {
+		case tok == "," || tok == "and":
+			p.next()
+		case tok == "every":
+			p.next()
+			err = p.parseEvery()
+		case tok == "hourly":
+			p.next()
+			err = p.setFreq(tok, "0", "*")
+		case tok == "daily":
+			p.next()
+			err = p.setDays("*", "?")
+		case tok == "weekly":
+			err = ambiguous("'weekly' does not tell the day (e.g. 'every monday')")
+		case tok == "monthly":
+			p.next()
+			p.monthly = true
+		case tok == "yearly" || tok == "annually":
+			p.next()
+			p.yearly = true
+		case tok == "at":
+			p.next()
+			err = p.parseAt()
+		case tok == "on":
+			p.next()
+			err = p.parseOn()
+		case tok == "in":
+			p.next()
+			err = p.parseIn()
+		case tok == "from" || tok == "between":
+			p.next()
+			err = p.parseWindow(tok)
+		case tok == "during":
+			p.next()
+
+			if err = p.expect("business"); err == nil {
+				if err = p.expect("hours"); err == nil {
+					err = p.setHours("9-17")
+					p.business = true
+				}
+			}
+		case textRawFieldRegexp.MatchString(tok):
+			p.next()
+			err = p.setRawField(tok)
+		case textWeekday(tok) >= 0:
+			err = p.parseDayOfWeek()
+		case tok == "the" || tok == "last" || tok == "weekday" || tok == "nearest" || textOrdinalWord(tok) > 0 || textOrdinal(tok) > 0 || textNumberRegexp.MatchString(tok):
+			err = p.parseDay()
+		default:
+			err = p.unexpected("")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.build()
+}
+
+func (p *textParser) setFreq(freq string, minute string, hour string) error {
+	if p.freq != "" {
+		return ambiguous("'%s' and '%s'", p.freq, freq)
+	} else if len(p.times) > 0 {
+		return ambiguous("'%s' with times", freq)
+	}
+
+	p.freq = freq
+	p.minute = minute
+	p.hour = hour
+
+	return nil
+}
+
+func (p *textParser) setHours(hours string) error {
+	if p.hours != "" {
+		return ambiguous("hours are set twice")
+	}
+
+	p.hours = hours
+
+	return nil
+}
+
+func (p *textParser) setDays(dom string, dow string) error {
+	if (p.dom != "" || p.dow != "") && (p.dom != dom || p.dow != dow) {
+		return ambiguous("days are set twice (use ';' for separate schedules)")
+	}
+
+	p.dom = dom
+	p.dow = dow
+
+	return nil
+}
+
+func (p *textParser) setRawField(tok string) error {
+	m := textRawFieldRegexp.FindStringSubmatch(tok)
+	field, value := m[1], strings.ToUpper(m[2])
+
+	switch field {
+	case "minute":
+		if p.minute != "" && p.freq != "raw" {
+			return ambiguous("minutes are set twice")
+		}
+
+		p.freq = "raw"
+		p.minute = value
+	case "hour":
+		if p.hour != "" && p.freq != "raw" {
+			return ambiguous("hours are set twice")
+		}
+
+		p.freq = "raw"
+		p.hour = value
+	case "day-of-month":
+		return p.setDays(value, "?")
+	case "day-of-week":
+		return p.setDays("?", value)
+	case "month":
+		p.month = value
+	case "year":
+		p.year = value
+	}
+
+	return nil
+}
+
+func (p *textParser) parseEvery() error {
+	tok := p.peek()
+
+	switch {
+	case tok == "minute":
+		p.next()
+		return p.setFreq("every minute", "*", "*")
+	case tok == "hour":
+		p.next()
+		return p.setFreq("every hour", "0", "*")
+	case tok == "day":
+		p.next()
+		return p.setDays("*", "?")
+	case tok == "weekday":
+		p.next()
+		return p.setDays("?", "MON-FRI")
+	case tok == "weekend":
+		p.next()
+		return p.setDays("?", "SAT,SUN")
+	case tok == "month":
+		p.next()
+		p.monthly = true
+		return nil
+	case tok == "year":
+		p.next()
+		p.yearly = true
+		return nil
+	case tok == "other":
+		return ambiguous("'every other' cannot be written in cron")
+	case textWeekday(tok) >= 0:
+		return p.parseDayOfWeek()
+	case textOrdinalWord(tok) > 0 || tok == "last":
+		return p.parseDay()
+	}
+
+	n, err := strconv.Atoi(tok)
+
+	if err != nil || n < 1 {
+		return p.unexpected("minute', 'hour', 'day', 'weekday', '<weekday>' or '<number>")
+	}
+
+	p.next()
+	unit := p.next()
+
+	switch unit {
+	case "minute", "minutes":
+		if 60%n != 0 {
+			return ambiguous("every %d minutes restarts at every hour (use a divisor of 60)", n)
+		} else if n == 1 {
+			return p.setFreq("every minute", "*", "*")
+		}
+
+		return p.setFreq(fmt.Sprintf("every %d minutes", n), fmt.Sprintf("*/%d", n), "*")
+	case "hour", "hours":
+		if 24%n != 0 {
+			return ambiguous("every %d hours restarts at every day (use a divisor of 24)", n)
+		} else if n == 1 {
+			return p.setFreq("every hour", "0", "*")
+		}
+
+		return p.setFreq(fmt.Sprintf("every %d hours", n), "0", fmt.Sprintf("*/%d", n))
+	case "day", "days", "week", "weeks", "month", "months":
+		if n == 1 {
+			p.pos--
+			return p.parseEvery()
+		}
+
+		return ambiguous("every %d %s restarts at every month or year", n, unit)
+	}
+
+	p.pos--
+
+	return p.unexpected("minutes' or 'hours")
+}
+
+func (p *textParser) parseAt() error {
+	if p.accept("minute") {
+		n, err := strconv.Atoi(p.peek())
+
+		if err != nil || n < 0 || n > 59 {
+			return p.unexpected("<minute>")
+		}
+
+		p.next()
+		p.minuteAt = strconv.Itoa(n)
+
+		return nil
+	}
+
+	if p.freq != "" {
+		return ambiguous("'%s' with times", p.freq)
+	}
+
+	for {
+		tod, err := p.parseTime()
+
+		if err != nil {
+			return err
+		}
+
+		p.times = append(p.times, tod)
+
+		if (p.peek() == "," || p.peek() == "and") && p.pos+1 < len(p.tokens) && isTextTime(p.tokens[p.pos+1]) {
+			p.next()
+			continue
+		}
+
+		return nil
+	}
+}
+
+func isTextTime(tok string) bool {
+	return tok == "noon" || tok == "midnight" || textTimeRegexp.MatchString(tok)
+}
+
+// parseTime parses a time and returns the minutes of the day.
+func (p *textParser) parseTime() (int, error) {
+	tok := p.peek()
+
+	if tok == "noon" {
+		p.next()
+		return 12 * 60, nil
+	} else if tok == "midnight" {
+		p.next()
+		return 0, nil
+	}
+
+	m := textTimeRegexp.FindStringSubmatch(tok)
+
+	if m == nil {
+		return 0, p.unexpected("<time>")
+	}
+
+	p.next()
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	ampm := m[3]
+
+	if ampm == "" && (p.peek() == "am" || p.peek() == "pm") {
+		ampm = p.next()
+		tok += " " + ampm
+	}
+
+	if ampm != "" {
+		if hour < 1 || hour > 12 {
+			return 0, fmt.Errorf("invalid time '%s'", tok)
+		}
+
+		hour %= 12
+
+		if ampm == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, fmt.Errorf("invalid time '%s'", tok)
+	}
+
+	return hour*60 + minute, nil
+}
+
+func (p *textParser) parseWindow(from string) error {
+	to := "to"
+
+	if from == "between" {
+		to = "and"
+	}
+
+	tok := p.peek()
+
+	if textWeekday(tok) >= 0 {
+		start := textWeekday(p.next())
+
+		if err := p.expect(to); err != nil {
+			return err
+		}
+
+		end := textWeekday(p.peek())
+
+		if end < 0 {
+			return p.unexpected("<weekday>")
+		}
+
+		p.next()
+
+		return p.setDays("?", fmt.Sprintf("%s-%s", util.ShortWeekdayNames[start], util.ShortWeekdayNames[end]))
+	} else if textMonth(tok) > 0 {
+		start := textMonth(p.next())
+
+		if err := p.expect(to); err != nil {
+			return err
+		}
+
+		end := textMonth(p.peek())
+
+		if end < 0 {
+			return p.unexpected("<month>")
+		}
+
+		p.next()
+		p.month = fmt.Sprintf("%s-%s", util.ShortMonthNames[start-1], util.ShortMonthNames[end-1])
+
+		return nil
+	} else if n, err := strconv.Atoi(tok); err == nil && n >= minYear {
+		p.next()
+
+		if err := p.expect(to); err != nil {
+			return err
+		}
+
+		end, err := strconv.Atoi(p.peek())
+
+		if err != nil || end < minYear {
+			return p.unexpected("<year>")
+		}
+
+		p.next()
+		p.year = fmt.Sprintf("%d-%d", n, end)
+
+		return nil
+	}
+
+	start, err := p.parseTime()
+
+	if err != nil {
+		return err
+	}
+
+	if err := p.expect(to); err != nil {
+		return err
+	}
+
+	end, err := p.parseTime()
+
+	if err != nil {
+		return err
+	}
+
+	if start%60 != 0 || end%60 != 0 {
+		return ambiguous("'%s' takes hours", from)
+	}
+
+	return p.setHours(fmt.Sprintf("%d-%d", start/60, end/60))
+}
+
+func (p *textParser) parseIn() error {
+	months := []string{}
+	years := []string{}
+
+	for {
+		tok := p.peek()
+
+		if m := textMonth(tok); m > 0 {
+			months = append(months, util.ShortMonthNames[m-1])
+		} else if n, err := strconv.Atoi(tok); err == nil && n >= minYear {
+			years = append(years, tok)
+		} else {
+			return p.unexpected("<month>' or '<year>")
+		}
+
+		p.next()
+
+		if (p.peek() == "," || p.peek() == "and") && p.pos+1 < len(p.tokens) {
+			if after := p.tokens[p.pos+1]; textMonth(after) > 0 || textYearsRegexp.MatchString(after) {
+				p.next()
+				continue
+			}
+		}
+
+		break
+	}
+
+	if len(months) > 0 {
+		p.month = strings.Join(months, ",")
+	}
+
+	if len(years) > 0 {
+		p.year = strings.Join(years, ",")
+	}
+
+	return nil
+}
+
+func (p *textParser) parseOn() error {
+	p.accept("the")
+	tok := p.peek()
+
+	switch {
+	case tok == "weekdays":
+		p.next()
+		return p.setDays("?", "MON-FRI")
+	case tok == "weekends":
+		p.next()
+		return p.setDays("?", "SAT,SUN")
+	case textWeekday(tok) >= 0:
+		return p.parseDayOfWeek()
+	}
+
+	return p.parseDay()
+}
+
+func (p *textParser) parseDayOfWeek() error {
+	wdays := []string{}
+
+	for {
+		w := textWeekday(p.peek())
+
+		if w < 0 {
+			return p.unexpected("<weekday>")
+		}
+
+		p.next()
+		wdays = append(wdays, util.ShortWeekdayNames[w])
+
+		if (p.peek() == "," || p.peek() == "and") && p.pos+1 < len(p.tokens) && textWeekday(p.tokens[p.pos+1]) >= 0 {
+			p.next()
+			continue
+		}
+
+		break
+	}
+
+	return p.setDays("?", strings.Join(wdays, ","))
+}
+
+// parseDay parses days of the month such as "the 1st", "the last friday" and "the weekday nearest the 15th".
+func (p *textParser) parseDay() error {
+	p.accept("the")
+	tok := p.peek()
+	var dom, dow string
+
+	switch {
+	case tok == "last":
+		p.next()
+
+		if p.accept("day") {
+			dom = "L"
+		} else if p.accept("weekday") {
+			dom = "LW"
+		} else if w := textWeekday(p.peek()); w >= 0 {
+			p.next()
+			dow = util.ShortWeekdayNames[w] + "L"
+		} else {
+			return p.unexpected("day', 'weekday' or '<weekday>")
+		}
+	case (textOrdinalWord(tok) > 0 || textOrdinal(tok) > 0) && p.pos+1 < len(p.tokens) && textWeekday(p.tokens[p.pos+1]) >= 0:
+		nth := textOrdinalWord(p.next())
+
+		if nth == 0 {
+			nth = textOrdinal(tok)
+		}
+
+		w := textWeekday(p.next())
+
+		if nth == 5 {
+			return ambiguous("the %s %s does not exist in every month (use 'the last %s')", tok, textWeekdayNames[w], textWeekdayNames[w])
+		} else if nth > 5 {
+			return fmt.Errorf("the %s %s does not exist in any month", tok, textWeekdayNames[w])
+		}
+
+		dow = fmt.Sprintf("%s#%d", util.ShortWeekdayNames[w], nth)
+	case tok == "weekday" || tok == "nearest":
+		p.next()
+
+		if tok == "weekday" {
+			if err := p.expect("nearest"); err != nil {
+				return err
+			}
+		} else if err := p.expect("weekday"); err != nil {
+			return err
+		} else {
+			p.accept("to")
+		}
+
+		p.accept("the")
+		day := textOrdinal(p.peek())
+
+		if day < 1 {
+			return p.unexpected("<ordinal day>")
+		}
+
+		p.next()
+		dom = fmt.Sprintf("%dW", day)
+	case textOrdinal(tok) > 0:
+		days := []string{}
+
+		for {
+			day := textOrdinal(p.peek())
+
+			if day < 1 {
+				return p.unexpected("<ordinal day>")
+			}
+
+			p.next()
+			days = append(days, strconv.Itoa(day))
+
+			if p.peek() == "," || p.peek() == "and" {
+				i := p.pos + 1
+
+				if i < len(p.tokens) && p.tokens[i] == "the" {
+					i++
+				}
+
+				if i < len(p.tokens) && textOrdinal(p.tokens[i]) > 0 {
+					p.pos = i
+					continue
+				}
+			}
+
+			break
+		}
+
+		dom = strings.Join(days, ",")
+	default:
+		n, err := strconv.Atoi(tok)
+
+		if err != nil || n < 1 || n > 30 {
+			return p.unexpected("<day>")
+		}
+
+		p.next()
+
+		for _, w := range []string{"days", "before", "the", "last", "day"} {
+			if w == "days" && n == 1 && p.accept("day") {
+				continue
+			}
+
+			if err := p.expect(w); err != nil {
+				return err
+			}
+		}
+
+		dom = fmt.Sprintf("L-%d", n)
+	}
+
+	// "of the month", "of each month", "of every month"
+	if p.accept("of") {
+		if !p.accept("the", "each", "every") {
+			if m := textMonth(p.peek()); m > 0 {
+				p.next()
+				p.month = util.ShortMonthNames[m-1]
+			} else {
+				return p.unexpected("the', 'each', 'every' or '<month>")
+			}
+		} else if err := p.expect("month"); err != nil {
+			return err
+		}
+	}
+
+	if dom != "" {
+		return p.setDays(dom, "?")
+	}
+
+	return p.setDays("?", dow)
+}
+
+func (p *textParser) build() ([]*Expression, error) {
+	minute, hour := p.minute, p.hour
+
+	if p.minuteAt != "" {
+		if p.freq == "" || minute != "0" {
+			return nil, ambiguous("'at minute %s' needs 'every hour' or 'every N hours'", p.minuteAt)
+		}
+
+		minute = p.minuteAt
+	}
+
+	if p.hours != "" {
+		if len(p.times) > 0 {
+			return nil, ambiguous("hours with times")
+		} else if p.freq == "" {
+			return nil, ambiguous("hours without a frequency (e.g. 'every hour')")
+		} else if hour == "*" {
+			hour = p.hours
+		} else if strings.HasPrefix(hour, "*/") {
+			hour = p.hours + hour[1:]
+		} else {
+			return nil, ambiguous("hours are set twice")
+		}
+	}
+
+	if p.freq == "raw" {
+		if minute == "" {
+			minute = "0"
+		}
+
+		if hour == "" {
+			hour = "0"
+		}
+	}
+
+	dom, dow, month, year := p.dom, p.dow, p.month, p.year
+
+	if p.yearly && month == "" {
+		month = "JAN"
+	}
+
+	if (p.monthly || p.yearly) && dom == "" && dow == "" {
+		dom = "1"
+	}
+
+	if p.business && dom == "" && dow == "" {
+		dow = "MON-FRI"
+	}
+
+	if dom == "" && dow == "" {
+		dom = "*"
+	}
+
+	if dom == "" {
+		dom = "?"
+	} else if dow == "" {
+		dow = "?"
+	}
+
+	if month == "" {
+		month = "*"
+	}
+
+	if year == "" {
+		year = "*"
+	}
+
+	days := fmt.Sprintf("%s %s %s %s", dom, month, dow, year)
+	exps := []string{}
+
+	if p.freq != "" {
+		exps = append(exps, fmt.Sprintf("%s %s %s", minute, hour, days))
+	} else if len(p.times) == 0 {
+		exps = append(exps, "0 0 "+days)
+	} else {
+		// the hours that share the minutes are in one expression
+		byHour := map[int][]int{}
+
+		for _, tod := range p.times {
+			byHour[tod/60] = append(byHour[tod/60], tod%60)
+		}
+
+		keys := []string{}
+		hours := map[string][]int{}
+
+		for h := 0; h <= 23; h++ {
+			minutes, ok := byHour[h]
+
+			if !ok {
+				continue
+			}
+
+			sort.Ints(minutes)
+			minutes = uniqInts(minutes)
+			k := listString(minutes, 0, 59, 59, strconv.Itoa)
+
+			if _, ok := hours[k]; !ok {
+				keys = append(keys, k)
+			}
+
+			hours[k] = append(hours[k], h)
+		}
+
+		for _, k := range keys {
+			exps = append(exps, fmt.Sprintf("%s %s %s", k, listString(hours[k], 0, 23, 23, strconv.Itoa), days))
+		}
+	}
+
+	exprs := make([]*Expression, 0, len(exps))
+
+	for _, exp := range exps {
+		cron, err := Parse(exp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, cron)
+	}
+
+	if err := checkTextDays(dom, exprs[0].Month); err != nil {
+		return nil, err
+	}
+
+	return exprs, nil
+}
+
+// checkTextDays returns an error if a day of the month does not exist in any of the months.
+func checkTextDays(dom string, month *MonthField) error {
+	months := []string{}
+	maxDays := 0
+
+	for m := time.January; m <= time.December; m++ {
+		// February has the 29th in a leap year
+		if month.Match(time.Date(2000, m, 1, 0, 0, 0, 0, time.UTC)) {
+			months = append(months, textMonthNames[m-1])
+			maxDays = max(maxDays, util.DaysIn(2000, m))
+		}
+	}
+
+	for _, s := range strings.Split(dom, ",") {
+		day, err := strconv.Atoi(strings.TrimSuffix(s, "W"))
+
+		if err == nil && day > maxDays {
+			return fmt.Errorf("the %s does not exist in %s", textOrdinalString(day), textJoin(months))
+		}
+	}
+
+	return nil
+}
+
+func uniqInts(vs []int) []int {
+	uniq := vs[:0]
+
+	for i, v := range vs {
+		if i == 0 || v != vs[i-1] {
+			uniq = append(uniq, v)
+		}
+	}
+
+	return uniq
+}
+
+// textWeekday returns the weekday of a name such as "monday", "mondays" and "mon", or -1.
+func textWeekday(s string) int {
+	for i, name := range textWeekdayNames {
+		if s == name || s == name+"s" || s == name[:3] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// textMonth returns the month of a name such as "january" and "jan", or 0.
+func textMonth(s string) int {
+	for i, name := range textMonthNames {
+		if s == name || s == name[:3] {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+func textOrdinalWord(s string) int {
+	for i, w := range textOrdinalWords {
+		if s == w {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// textOrdinal returns the day of an ordinal such as "1st" and "15th", or 0.
+func textOrdinal(s string) int {
+	m := textOrdinalRegexp.FindStringSubmatch(s)
+
+	if m == nil {
+		return 0
+	}
+
+	n, _ := strconv.Atoi(m[1])
+
+	if n < 1 || n > 31 {
+		return 0
+	}
+
+	return n
+}
+
+func textOrdinalString(n int) string {
+	suffix := "th"
+
+	if n < 11 || n > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+// Describe returns an English phrase of the expression that FromText parses back.
+// The fields that have no phrase are written as e.g. "minute(5-10)".
+func (v *Expression) Describe() string {
+	phrases := []string{v.describeTime()}
+
+	days := v.describeDays()
+	months := []string{}
+
+	if !v.Month.isWildcard() {
+		for month := time.January; month <= time.December; month++ {
+			if v.Month.Match(time.Date(2000, month, 1, 0, 0, 0, 0, time.UTC)) {
+				months = append(months, textMonthNames[month-1])
+			}
+		}
+	}
+
+	// "on the 1st of january"
+	if len(months) == 1 && strings.HasSuffix(days, " of the month") {
+		days = strings.TrimSuffix(days, "the month") + months[0]
+		months = nil
+	}
+
+	if days != "" {
+		phrases = append(phrases, days)
+	}
+
+	if len(months) >= 3 && textMonth(months[len(months)-1])-textMonth(months[0]) == len(months)-1 {
+		phrases = append(phrases, fmt.Sprintf("from %s to %s", months[0], months[len(months)-1]))
+	} else if len(months) > 0 {
+		phrases = append(phrases, "in "+textJoin(months))
+	}
+
+	if s := v.Year.String(); s != "*" {
+		if textYearsRegexp.MatchString(s) {
+			phrases = append(phrases, "in "+textJoin(strings.Split(s, ",")))
+		} else {
+			phrases = append(phrases, fmt.Sprintf("year(%s)", s))
+		}
+	}
+
+	return strings.Join(phrases, " ")
+}
+
+func (v *MonthField) isWildcard() bool {
+	return len(v.Exps) == 1 && v.Exps[0].Wildcard && v.Exps[0].Bottom == nil
+}
+
+// textJoin joins words as "a, b and c".
+func textJoin(words []string) string {
+	if len(words) <= 1 {
+		return strings.Join(words, "")
+	}
+
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
+
+func textTime(tod int) string {
+	switch tod {
+	case 0:
+		return "midnight"
+	case 12 * 60:
+		return "noon"
+	}
+
+	return fmt.Sprintf("%d:%02d", tod/60, tod%60)
+}
+
+// textStep returns the step of values that start at 0 and divide max, or 0.
+func textStep(values []int, max int) int {
+	if len(values) < 2 || values[0] != 0 {
+		return 0
+	}
+
+	step := values[1]
+
+	if step < 2 || max%step != 0 || len(values) != max/step {
+		return 0
+	}
+
+	for i, v := range values {
+		if v != i*step {
+			return 0
+		}
+	}
+
+	return step
+}
+
+// textRange returns the first and the last values if values are consecutive.
+func textRange(values []int) (int, int, bool) {
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1]+1 {
+			return 0, 0, false
+		}
+	}
+
+	return values[0], values[len(values)-1], true
+}
+
+func (v *Expression) describeTime() string {
+	minutes := []int{}
+	hours := []int{}
+
+	for minute := 0; minute <= 59; minute++ {
+		if v.Minute.Match(time.Date(2000, 1, 1, 0, minute, 0, 0, time.UTC)) {
+			minutes = append(minutes, minute)
+		}
+	}
+
+	for hour := 0; hour <= 23; hour++ {
+		if v.Hour.Match(time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC)) {
+			hours = append(hours, hour)
+		}
+	}
+
+	raw := fmt.Sprintf("minute(%s) hour(%s)", v.Minute, v.Hour)
+
+	if len(minutes) == 0 || len(hours) == 0 {
+		return raw
+	}
+
+	// the hours of "every N minutes" and "every hour"
+	window := ""
+
+	if len(hours) < 24 {
+		if start, end, ok := textRange(hours); ok && start < end {
+			window = fmt.Sprintf(" from %d to %d", start, end)
+		}
+	}
+
+	if len(minutes) == 60 || textStep(minutes, 60) > 0 {
+		freq := "every minute"
+
+		if step := textStep(minutes, 60); step > 0 {
+			freq = fmt.Sprintf("every %d minutes", step)
+		}
+
+		if len(hours) == 24 {
+			return freq
+		} else if window != "" {
+			return freq + window
+		}
+
+		return raw
+	}
+
+	if len(minutes) == 1 {
+		at := ""
+
+		if minutes[0] != 0 {
+			at = fmt.Sprintf(" at minute %d", minutes[0])
+		}
+
+		if len(hours) == 24 {
+			return "every hour" + at
+		} else if step := textStep(hours, 24); step > 0 {
+			return fmt.Sprintf("every %d hours%s", step, at)
+		} else if window != "" && len(hours) >= 3 {
+			return "every hour" + window + at
+		}
+	}
+
+	if len(minutes)*len(hours) > 6 {
+		return raw
+	}
+
+	times := []string{}
+
+	for _, h := range hours {
+		for _, m := range minutes {
+			times = append(times, textTime(h*60+m))
+		}
+	}
+
+	return "at " + textJoin(times)
+}
+
+func (v *Expression) describeDays() string {
+	if v.DayOfMonth.Any {
+		exps := v.DayOfWeek.Exps
+
+		if len(exps) == 1 && exps[0].Nth != nil && exps[0].Nth.Nth >= 1 && exps[0].Nth.Nth <= 4 {
+			return fmt.Sprintf("on the %s %s of the month", textOrdinalWords[exps[0].Nth.Nth-1], textWeekdayNames[exps[0].Nth.Wday.Int()])
+		} else if len(exps) == 1 && exps[0].Last != nil && exps[0].Last.Wday != nil {
+			return fmt.Sprintf("on the last %s of the month", textWeekdayNames[exps[0].Last.Wday.Int()])
+		}
+
+		wdays := []string{}
+
+		for _, e := range exps {
+			if e.Nth != nil || (e.Last != nil && e.Last.Wday != nil) {
+				return fmt.Sprintf("day-of-week(%s)", v.DayOfWeek)
+			}
+		}
+
+		// 2000-01-02 is Sunday
+		for wday := 0; wday <= 6; wday++ {
+			if v.DayOfWeek.Match(time.Date(2000, 1, 2+wday, 0, 0, 0, 0, time.UTC)) {
+				wdays = append(wdays, textWeekdayNames[wday])
+			}
+		}
+
+		switch strings.Join(wdays, ",") {
+		case strings.Join(textWeekdayNames, ","):
+			return ""
+		case "monday,tuesday,wednesday,thursday,friday":
+			return "on weekdays"
+		case "sunday,saturday":
+			return "on weekends"
+		}
+
+		return "on " + textJoin(wdays)
+	}
+
+	exps := v.DayOfMonth.Exps
+
+	if len(exps) == 1 {
+		e := exps[0]
+
+		switch {
+		case e.Last != nil && e.Last.Int() == 0:
+			return "on the last day of the month"
+		case e.Last != nil:
+			return fmt.Sprintf("%d days before the last day of the month", e.Last.Int())
+		case e.LastWeekday != nil:
+			return "on the last weekday of the month"
+		case e.NearestWeekday != nil:
+			return fmt.Sprintf("on the weekday nearest the %s of the month", textOrdinalString(e.NearestWeekday.Int()))
+		case e.Wildcard && e.Bottom == nil:
+			return ""
+		}
+	}
+
+	days := []string{}
+
+	for _, e := range exps {
+		if e.NearestWeekday != nil || e.LastWeekday != nil || e.Last != nil || e.Wildcard || e.Bottom != nil || e.Range != nil {
+			return fmt.Sprintf("day-of-month(%s)", v.DayOfMonth)
+		}
+
+		days = append(days, textOrdinalString(e.Number.Int()))
+	}
+
+	return "on the " + textJoin(days) + " of the month"
+}